@@ -0,0 +1,167 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	goconcurrentlogger "github.com/ralvarezdev/go-concurrent-logger"
+)
+
+var (
+	// errMeasureReceived is the internal error used to stop reading the lines of a trial once a measure is parsed
+	errMeasureReceived = errors.New("measure received")
+)
+
+// DetectBaudRate launches ultra_simple briefly at each candidate baud rate, and returns the first one that yields
+// parseable measure lines within the timeout. Each trial process is stopped before the next one is launched. It's a
+// method rather than a free function since it launches the ultra_simple executable of the handler with its extra
+// arguments and parser config, and it marks the handler as running so no run can share the RPLiDAR meanwhile.
+//
+// Parameters:
+//
+// port: SerialCommunication port for the RPLiDAR.
+// candidates: The baud rates to try in order, e.g. the ones of the Slamtec models.
+// timeout: Time to wait for a measure at each baud rate.
+//
+// Returns:
+//
+// The detected baud rate, or an error if any parameter is not valid, if the handler is running, or if no candidate
+// yielded measures.
+func (h *DefaultHandler) DetectBaudRate(
+	port string,
+	candidates []int,
+	timeout time.Duration,
+) (int, error) {
+	// Check if the handler executes ultra_simple
+	if strings.TrimSpace(h.ultraSimplePath) == "" {
+		return 0, ErrEmptyUltraSimplePath
+	}
+
+	// Check if the port is empty
+	if strings.TrimSpace(port) == "" {
+		return 0, ErrEmptyPort
+	}
+
+	// Check if there are candidates
+	if len(candidates) == 0 {
+		return 0, ErrEmptyBaudRateCandidates
+	}
+
+	// Check if the timeout is valid
+	if timeout <= 0 {
+		return 0, ErrInvalidDetectionTimeout
+	}
+
+	h.handlerMutex.Lock()
+
+	// Check if it's closed
+	if h.isClosed.Load() {
+		h.handlerMutex.Unlock()
+		return 0, ErrHandlerClosed
+	}
+
+	// Check if it's already running, since the RPLiDAR can't be shared
+	if h.IsRunning() {
+		h.handlerMutex.Unlock()
+		return 0, ErrHandlerAlreadyRunning
+	}
+
+	// Mark the handler as running to prevent a run during the detection
+	h.isRunning.Store(true)
+	defer h.isRunning.Store(false)
+
+	h.handlerMutex.Unlock()
+
+	// Create a logger producer for the detection, so the producer of the handler runs is left untouched
+	loggerProducer, err := h.logger.NewProducer(
+		HandlerLoggerProducerTag,
+		h.debug,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create handler logger producer: %w", err)
+	}
+	defer loggerProducer.Close()
+
+	for _, baudRate := range candidates {
+		isDetected, err := h.tryBaudRate(port, baudRate, timeout, loggerProducer)
+		if err != nil {
+			return 0, err
+		}
+		if isDetected {
+			loggerProducer.Info(
+				fmt.Sprintf(
+					"Detected baud rate %d on port %s",
+					baudRate,
+					port,
+				),
+			)
+			return baudRate, nil
+		}
+	}
+	return 0, ErrBaudRateNotDetected
+}
+
+// tryBaudRate launches ultra_simple at the given baud rate until a measure is parsed or the timeout elapses.
+//
+// Parameters:
+//
+// port: SerialCommunication port for the RPLiDAR.
+// baudRate: Baud rate to try.
+// timeout: Time to wait for a measure.
+// loggerProducer: Logger producer of the detection.
+//
+// Returns:
+//
+// True if a measure was parsed, or an error if the process couldn't be started.
+func (h *DefaultHandler) tryBaudRate(
+	port string,
+	baudRate int,
+	timeout time.Duration,
+	loggerProducer goconcurrentlogger.LoggerProducer,
+) (bool, error) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+	defer cancelFn()
+
+	// Arguments (do not include the executable itself)
+	args := []string{
+		UltraSimpleChannelArgument,
+		UltraSimpleSerialArgument,
+		port,
+		strconv.Itoa(baudRate),
+	}
+	args = append(args, h.extraArgs...)
+
+	// Execute the command with a context
+	cmd := exec.CommandContext(ctx, h.ultraSimplePath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false, fmt.Errorf("stdout pipe error: %w", err)
+	}
+	if err = cmd.Start(); err != nil {
+		return false, fmt.Errorf("start command error: %w", err)
+	}
+
+	// Read the lines until the first measure
+	err = h.scanLines(
+		ctx,
+		StdoutTag,
+		stdout,
+		func(line string) error {
+			if _, err := NewMeasureFromStringWithConfig(line, h.parserConfig, false, 0); err == nil {
+				return errMeasureReceived
+			}
+			return nil
+		},
+		loggerProducer,
+	)
+
+	// Stop the trial process
+	_ = stdout.Close()
+	_ = h.stopProcess(cmd, loggerProducer)
+	return errors.Is(err, errMeasureReceived), nil
+}
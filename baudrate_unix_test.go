@@ -0,0 +1,73 @@
+//go:build !windows
+
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeUltraSimpleScript is a fake ultra_simple that prints a measure only at the 256000 baud rate, and then waits to be
+// stopped
+const fakeUltraSimpleScript = `#!/bin/sh
+if [ "$4" = "256000" ]; then
+	echo "theta: 10.00 Dist: 01000.00 Q: 47"
+fi
+exec sleep 5
+`
+
+// TestDetectBaudRate checks that the first candidate yielding measures is detected, and that the logger producer of
+// the handler runs is left untouched by the detection.
+func TestDetectBaudRate(t *testing.T) {
+	ultraSimplePath := filepath.Join(t.TempDir(), "ultra_simple")
+	if err := os.WriteFile(ultraSimplePath, []byte(fakeUltraSimpleScript), 0o755); err != nil {
+		t.Fatalf("failed to write the fake ultra_simple: %v", err)
+	}
+
+	h, err := NewDefaultHandler(
+		SlamtecC1BaudRate,
+		"/dev/ttyFAKE0",
+		false,
+		0,
+		0,
+		nopLogger{},
+		ultraSimplePath,
+		10000,
+		1,
+		false,
+		WithSkipPortCheck(),
+		WithCloseTimeout(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create the handler: %v", err)
+	}
+
+	baudRate, err := h.DetectBaudRate(
+		"/dev/ttyFAKE0",
+		[]int{SlamtecC1BaudRate, SlamtecA2BaudRate, SlamtecA1BaudRate},
+		500*time.Millisecond,
+	)
+	if err != nil {
+		t.Fatalf("failed to detect the baud rate: %v", err)
+	}
+	if baudRate != SlamtecA2BaudRate {
+		t.Errorf("expected the baud rate %d, got %d", SlamtecA2BaudRate, baudRate)
+	}
+
+	// Check that the detection didn't replace the logger producer of the handler runs nor left it running
+	if h.handlerLoggerProducer != nil {
+		t.Errorf("expected the logger producer of the handler runs to be untouched, got %v", h.handlerLoggerProducer)
+	}
+	if h.IsRunning() {
+		t.Error("expected the handler to not be running after the detection")
+	}
+
+	// Check that no candidate yielding measures is reported
+	_, err = h.DetectBaudRate("/dev/ttyFAKE0", []int{SlamtecA1BaudRate}, 200*time.Millisecond)
+	if !errors.Is(err, ErrBaudRateNotDetected) {
+		t.Errorf("expected %v, got %v", ErrBaudRateNotDetected, err)
+	}
+}
@@ -0,0 +1,89 @@
+package go_rplidar_sdk_handler
+
+import (
+	"fmt"
+	"io"
+)
+
+// startCapture starts the goroutine that writes the captured stdout lines to the capture writer.
+//
+// Returns:
+//
+// A function that stops the capture once the queued lines are written, or nil if there's no capture writer.
+func (h *DefaultHandler) startCapture() func() {
+	// Check if there's a capture writer
+	if h.captureWriter == nil {
+		return nil
+	}
+
+	captureCh := make(chan string, CaptureChannelSize)
+	doneCh := make(chan struct{})
+	h.captureMutex.Lock()
+	h.captureCh = captureCh
+	h.captureMutex.Unlock()
+
+	go func() {
+		defer close(doneCh)
+		h.writeCapture(h.captureWriter, captureCh)
+	}()
+
+	return func() {
+		h.captureMutex.Lock()
+		h.captureCh = nil
+		h.captureMutex.Unlock()
+
+		// Wait until the queued lines are written
+		close(captureCh)
+		<-doneCh
+	}
+}
+
+// writeCapture writes the captured lines to the writer until the channel is closed. After the first write error the
+// remaining lines are discarded.
+//
+// Parameters:
+//
+// w: Writer to write the captured lines to.
+// captureCh: Channel with the captured lines.
+func (h *DefaultHandler) writeCapture(w io.Writer, captureCh <-chan string) {
+	failed := false
+	for line := range captureCh {
+		// Check if a previous write failed
+		if failed {
+			continue
+		}
+
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			h.handlerLoggerProducer.Warning(
+				fmt.Sprintf(
+					"Failed to write to the capture writer, stopping the capture: %v",
+					err,
+				),
+			)
+			failed = true
+		}
+	}
+}
+
+// captureLine queues a raw stdout line to be written to the capture writer without blocking.
+//
+// Parameters:
+//
+// line: The raw line to capture.
+func (h *DefaultHandler) captureLine(line string) {
+	h.captureMutex.Lock()
+	defer h.captureMutex.Unlock()
+
+	// Check if the capture is running
+	if h.captureCh == nil {
+		return
+	}
+
+	select {
+	case h.captureCh <- line:
+	default:
+		if h.handlerLoggerProducer.IsDebug() {
+			h.handlerLoggerProducer.Debug("Capture channel is full, skipping line.")
+		}
+	}
+}
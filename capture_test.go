@@ -0,0 +1,95 @@
+package go_rplidar_sdk_handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingWriter fails every write and counts the calls.
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write([]byte) (int, error) {
+	w.writes++
+	return 0, errors.New("write failed")
+}
+
+// TestCapture checks that the raw stdout lines are captured, and that the other streams are not.
+func TestCapture(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		input    string
+		expected string
+	}{
+		{
+			name:     "stdout lines",
+			tag:      StdoutTag,
+			input:    "S 0.00 500.00 47\n  30.00 530.00 47  \n",
+			expected: "S 0.00 500.00 47\n  30.00 530.00 47  \n",
+		},
+		{name: "stderr lines", tag: StderrTag, input: "some error\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := newTestLineHandler(t, WithCaptureWriter(&buf))
+
+			stopCapture := h.startCapture()
+			if stopCapture == nil {
+				t.Fatal("expected a capture stop function")
+			}
+			err := h.scanLines(
+				context.Background(),
+				test.tag,
+				strings.NewReader(test.input),
+				func(string) error { return nil },
+				nopLoggerProducer{},
+			)
+			stopCapture()
+			if err != nil {
+				t.Fatalf("failed to scan the lines: %v", err)
+			}
+
+			if buf.String() != test.expected {
+				t.Errorf("expected the capture %q, got %q", test.expected, buf.String())
+			}
+
+			// Check that the lines after the capture stopped are not captured
+			h.captureLine("S 0.00 600.00 47")
+			if buf.String() != test.expected {
+				t.Errorf("expected no capture after it stopped, got %q", buf.String())
+			}
+		})
+	}
+}
+
+// TestCaptureWithoutWriter checks that the capture doesn't start without a capture writer.
+func TestCaptureWithoutWriter(t *testing.T) {
+	h := newTestLineHandler(t)
+	if stopCapture := h.startCapture(); stopCapture != nil {
+		t.Error("expected no capture stop function without a capture writer")
+	}
+	h.captureLine("S 0.00 500.00 47")
+}
+
+// TestCaptureFailingWriter checks that the capture stops writing after the first write error.
+func TestCaptureFailingWriter(t *testing.T) {
+	w := &failingWriter{}
+	h := newTestLineHandler(t, WithCaptureWriter(w))
+
+	stopCapture := h.startCapture()
+	for _, line := range testScanLines(1, 90) {
+		h.captureLine(line)
+	}
+	stopCapture()
+
+	if w.writes != 1 {
+		t.Errorf("expected a single write, got %d", w.writes)
+	}
+}
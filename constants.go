@@ -8,13 +8,34 @@ const (
 	// SlamtecC1BaudRate is the RPLiDAR C1 baud rate
 	SlamtecC1BaudRate = 460800
 
+	// SlamtecA1BaudRate is the RPLiDAR A1 baud rate
+	SlamtecA1BaudRate = 115200
+
+	// SlamtecA2BaudRate is the RPLiDAR A2 baud rate
+	SlamtecA2BaudRate = 256000
+
+	// SlamtecC1QualityScale is the maximum quality reported by the RPLiDAR C1
+	SlamtecC1QualityScale = 255
+
+	// SlamtecA1QualityScale is the maximum quality reported by the RPLiDAR A1
+	SlamtecA1QualityScale = 63
+
+	// SlamtecA2QualityScale is the maximum quality reported by the RPLiDAR A2
+	SlamtecA2QualityScale = 63
+
+	// DefaultQualityScale is the default maximum quality, used when the RPLiDAR model is unknown
+	DefaultQualityScale = 255
+
 	// HandlerInitializedMessage is the message logged when the handler is initialized
 	HandlerInitializedMessage = "RPLiDAR handler initialized"
 
 	// HandlerReadyMessage is the message logged when the handler is ready
 	HandlerReadyMessage = "RPLiDAR handler is ready"
 
-	// CloseTimeout is the timeout for closing the handler
+	// HandlerReceivingMeasuresMessage is the message logged when the first valid measure is received
+	HandlerReceivingMeasuresMessage = "RPLiDAR handler is receiving measures"
+
+	// CloseTimeout is the default timeout for closing the handler
 	CloseTimeout = 5 * time.Second
 
 	// UltraSimpleChannelArgument is the argument for the channel in the ultra_simple executable
@@ -34,16 +55,87 @@ const (
 
 	// QualityIndex is the index of the quality in the measure string
 	QualityIndex = 2
+
+	// DeviceHealthGood is the health status of a RPLiDAR working properly
+	DeviceHealthGood = "Good"
+
+	// DeviceHealthWarning is the health status of a RPLiDAR with a recoverable issue
+	DeviceHealthWarning = "Warning"
+
+	// DeviceHealthError is the health status of a RPLiDAR that must be rebooted
+	DeviceHealthError = "Error"
+
+	// ParserPoolQueueSize is the number of stdout lines that can be queued to the parser workers
+	ParserPoolQueueSize = 256
+
+	// CaptureChannelSize is the size of the channel of the stdout lines waiting to be written to the capture writer
+	CaptureChannelSize = 1024
+
+	// MaxBannerLines is the maximum number of banner lines kept as device info
+	MaxBannerLines = 64
+
+	// RotationEventsChannelSize is the buffer size of the rotation events channel
+	RotationEventsChannelSize = 1
+
+	// ParseErrorsChannelSize is the size of the parse errors channel
+	ParseErrorsChannelSize = 16
+
+	// StderrHistorySize is the number of recent stderr lines kept by the handler
+	StderrHistorySize = 50
+
+	// DefaultBucketsPerDegree is the default number of buckets per degree of the measures grid
+	DefaultBucketsPerDegree = 1
+
+	// MaxBucketsPerDegree is the maximum number of buckets per degree of the measures grid
+	MaxBucketsPerDegree = 100
+
+	// ScanFrequencySamples is the number of recent rotation timestamps used to compute the scan frequency
+	ScanFrequencySamples = 10
+
+	// DefaultScanHistorySize is the default number of complete scans kept in the scan history
+	DefaultScanHistorySize = 4
+
+	// DefaultHealthMaxDataAge is the default time without any parsed measure after which the handler is unhealthy
+	DefaultHealthMaxDataAge = 2 * time.Second
+
+	// DefaultHealthMinScanFrequency is the default scan frequency in Hz below which the handler is unhealthy
+	DefaultHealthMinScanFrequency = 1.0
+
+	// DefaultHealthMinCoverage is the default fraction of angles with a valid measure below which the handler is
+	// unhealthy
+	DefaultHealthMinCoverage = 0.25
+
+	// NoSmoothingAlpha is the smoothing alpha that keeps each new distance as is
+	NoSmoothingAlpha = 1.0
+
+	// DefaultMaxLineLength is the default maximum length in bytes of a line read from ultra_simple
+	DefaultMaxLineLength = 256
+
+	// ScanHTTPFormatQueryParameter is the query parameter used to select the format of the scan HTTP handler
+	ScanHTTPFormatQueryParameter = "format"
+
+	// ScanHTTPSVGFormat is the format value used to request the SVG polar plot of the scan
+	ScanHTTPSVGFormat = "svg"
+
+	// ScanSVGSize is the width and height in pixels of the SVG polar plot of the scan
+	ScanSVGSize = 600
+
+	// DefaultMeasuresChannelSize is the default size of the measures channel of the handlers that don't take it as a
+	// parameter, such as the replay handler
+	DefaultMeasuresChannelSize = 360
+
+	// NoCorridorAngle is the angle returned by FreeCorridorWidth at both sides when there's no free corridor
+	NoCorridorAngle = -1
 )
 
 var (
 	// LinuxSlamtecC1Port is the RPLiDAR C1 default port in Linux systems
 	LinuxSlamtecC1Port = "/dev/ttyUSB0"
 
-	// InitialSizeBuffer is the initial size of the buffer for reading lines
+	// InitialSizeBuffer is the default initial size of the buffer for reading lines
 	InitialSizeBuffer = 1024 * 1024 // 1 MB
 
-	// MaxSizeBuffer is the maximum size of the buffer for reading lines
+	// MaxSizeBuffer is the default maximum size of the buffer for reading lines
 	MaxSizeBuffer = 1024 * 1024 * 10 // 10 MB
 
 	// StdoutTag is the tag for standard output logs
@@ -52,12 +144,56 @@ var (
 	// StderrTag is the tag for standard error logs
 	StderrTag = "STDERR"
 
-	// IgnoreFirstStdoutMessages is the number of initial stdout messages to ignore
-	IgnoreFirstStdoutMessages = 6
+	// ReplayTag is the tag for replay file logs
+	ReplayTag = "REPLAY"
+
+	// ReaderTag is the tag for reader logs
+	ReaderTag = "READER"
+
+	// IgnoreFirstStdoutMessages is the default number of initial stdout messages to ignore, the banner lines are
+	// detected dynamically, so no lines are ignored by default
+	IgnoreFirstStdoutMessages = 0
 
 	// HandlerLoggerProducerTag is the logger producer tag for RPLiDAR
 	HandlerLoggerProducerTag = "RPLiDAR_HANDLER"
 
+	// HandlerRetryLoggerProducerTag is the logger producer tag for the RPLiDAR handler retries
+	HandlerRetryLoggerProducerTag = "RPLiDAR_HANDLER_RETRY"
+
+	// MeasureLabels are the lowercase label tokens that some ultra_simple builds print before each measure field
+	MeasureLabels = []string{"theta:", "dist:", "q:"}
+
+	// ScanCSVHeader is the header of the scan CSV export
+	ScanCSVHeader = []string{"angle", "distance", "quality"}
+
 	// AttributesSeparator is the attributes separator
 	AttributesSeparator = ","
+
+	// DeviceHealthStatusNames maps the numeric health status codes of the SDK to their names
+	DeviceHealthStatusNames = map[int]string{
+		0: DeviceHealthGood,
+		1: DeviceHealthWarning,
+		2: DeviceHealthError,
+	}
+
+	// DefaultParserConfig is the default layout of the measure lines printed by ultra_simple
+	DefaultParserConfig = ParserConfig{
+		AngleIndex:    AngleIndex,
+		DistanceIndex: DistanceIndex,
+		QualityIndex:  QualityIndex,
+	}
+
+	// DefaultRetryPolicy is the default policy to relaunch ultra_simple after it exits unexpectedly
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts:    0,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+	}
+
+	// DefaultSubscriberBufferSize is the default buffer size of each subscriber channel
+	DefaultSubscriberBufferSize = 360
+
+	// DefaultSubscriberOverflowPolicy is the default policy applied when a subscriber channel is full
+	DefaultSubscriberOverflowPolicy = SubscriberOverflowPolicyDropNewest
 )
@@ -0,0 +1,205 @@
+package go_rplidar_sdk_handler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// DeviceInfo is the device metadata printed by ultra_simple before the measurement data starts.
+	DeviceInfo struct {
+		// Lines are the banner lines printed before the first measure
+		Lines []string
+
+		// SDKVersion is the version of the SDK ultra_simple was built with
+		SDKVersion string
+
+		// SerialNumber is the serial number of the RPLiDAR
+		SerialNumber string
+
+		// FirmwareVersion is the firmware version of the RPLiDAR
+		FirmwareVersion string
+
+		// HardwareRevision is the hardware revision of the RPLiDAR
+		HardwareRevision string
+
+		// HealthStatus is the last health status reported by ultra_simple, or empty if it wasn't reported
+		HealthStatus string
+
+		// HealthErrorCode is the error code of the last health status
+		HealthErrorCode int
+	}
+)
+
+var (
+	// sdkVersionRegexp matches the SDK version line, e.g. "Version: 2.0.0"
+	sdkVersionRegexp = regexp.MustCompile(`(?i)^\s*(?:sdk\s*)?version\s*:\s*(\S+)`)
+
+	// serialNumberRegexp matches the serial number line, e.g. "SLAMTEC LIDAR S/N: 5EB4E9F3C3E09CD4A7E69CF7"
+	serialNumberRegexp = regexp.MustCompile(`(?i)\bS/N\s*:\s*([0-9A-F]+)`)
+
+	// firmwareVersionRegexp matches the firmware version line, e.g. "Firmware Ver: 1.29"
+	firmwareVersionRegexp = regexp.MustCompile(`(?i)\bfirmware\s*ver(?:sion)?\s*:\s*(\S+)`)
+
+	// hardwareRevisionRegexp matches the hardware revision line, e.g. "Hardware Rev: 7"
+	hardwareRevisionRegexp = regexp.MustCompile(`(?i)\bhardware\s*rev(?:ision)?\s*:\s*(\S+)`)
+
+	// healthRegexp matches the health status line, e.g. "SLAMTEC Lidar health status : 0" or "Device health: Good", with
+	// an optional error code
+	healthRegexp = regexp.MustCompile(`(?i)\bhealth(?:\s+status)?\s*:\s*([a-z]+|\d+)(?:\D*?error\s*code\s*:?\s*(\d+))?`)
+
+	// internalErrorRegexp matches the line printed when the RPLiDAR reports an internal error
+	internalErrorRegexp = regexp.MustCompile(`(?i)\binternal error detected\b`)
+)
+
+// parseBannerLine fills the device info fields found in a banner line.
+//
+// Parameters:
+//
+// deviceInfo: The device info to fill.
+// line: The banner line to parse.
+func parseBannerLine(deviceInfo *DeviceInfo, line string) {
+	for _, field := range []struct {
+		regexp *regexp.Regexp
+		value  *string
+	}{
+		{sdkVersionRegexp, &deviceInfo.SDKVersion},
+		{serialNumberRegexp, &deviceInfo.SerialNumber},
+		{firmwareVersionRegexp, &deviceInfo.FirmwareVersion},
+		{hardwareRevisionRegexp, &deviceInfo.HardwareRevision},
+	} {
+		if matches := field.regexp.FindStringSubmatch(line); matches != nil {
+			*field.value = matches[1]
+		}
+	}
+}
+
+// recordBannerLine stores a line printed before the measurement data starts and parses the device info from it.
+//
+// Parameters:
+//
+// line: The banner line to record.
+func (h *DefaultHandler) recordBannerLine(line string) {
+	h.deviceInfoMutex.Lock()
+	defer h.deviceInfoMutex.Unlock()
+
+	// Check if the max number of banner lines has been reached
+	if len(h.deviceInfo.Lines) >= MaxBannerLines {
+		return
+	}
+	h.deviceInfo.Lines = append(h.deviceInfo.Lines, line)
+	parseBannerLine(&h.deviceInfo, line)
+}
+
+// resetDeviceInfo clears the device info of the previous run.
+func (h *DefaultHandler) resetDeviceInfo() {
+	h.deviceInfoMutex.Lock()
+	defer h.deviceInfoMutex.Unlock()
+	h.deviceInfo = DeviceInfo{}
+}
+
+// GetDeviceInfo returns the device metadata printed by ultra_simple before the measurement data started, including the
+// lines ignored at the start of the run.
+//
+// Returns:
+//
+// A copy of the device info of the current run, whose parsed fields are empty if they weren't printed.
+func (h *DefaultHandler) GetDeviceInfo() DeviceInfo {
+	h.deviceInfoMutex.Lock()
+	defer h.deviceInfoMutex.Unlock()
+
+	deviceInfo := h.deviceInfo
+	deviceInfo.Lines = append([]string(nil), h.deviceInfo.Lines...)
+	return deviceInfo
+}
+
+// parseHealthLine parses the health status reported in a line.
+//
+// Parameters:
+//
+// line: The line to parse.
+//
+// Returns:
+//
+// The health status, its error code, and false if the line doesn't report the health status.
+func parseHealthLine(line string) (status string, errorCode int, ok bool) {
+	// Check if the RPLiDAR reported an internal error
+	if internalErrorRegexp.MatchString(line) {
+		return DeviceHealthError, 0, true
+	}
+
+	matches := healthRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return "", 0, false
+	}
+	if matches[2] != "" {
+		errorCode, _ = strconv.Atoi(matches[2])
+	}
+
+	// Check if the status is the numeric code of the SDK
+	if code, err := strconv.Atoi(matches[1]); err == nil {
+		if status, ok = DeviceHealthStatusNames[code]; ok {
+			return status, errorCode, true
+		}
+		return matches[1], errorCode, true
+	}
+
+	// Normalize the known status names
+	for _, status = range DeviceHealthStatusNames {
+		if strings.EqualFold(status, matches[1]) {
+			return status, errorCode, true
+		}
+	}
+	return matches[1], errorCode, true
+}
+
+// checkDeviceHealth records the health status reported in a line, if any.
+//
+// Parameters:
+//
+// line: The line to check.
+//
+// Returns:
+//
+// An error wrapping ErrUnhealthyDevice if the health status is not good and the handler fails on an unhealthy device.
+func (h *DefaultHandler) checkDeviceHealth(line string) error {
+	status, errorCode, ok := parseHealthLine(line)
+	if !ok {
+		return nil
+	}
+
+	// Store the health status
+	h.deviceInfoMutex.Lock()
+	h.deviceInfo.HealthStatus = status
+	h.deviceInfo.HealthErrorCode = errorCode
+	h.deviceInfoMutex.Unlock()
+
+	// Check if the device is healthy
+	if status == DeviceHealthGood {
+		return nil
+	}
+	h.handlerLoggerProducer.Warning(
+		fmt.Sprintf(
+			"RPLiDAR health status is %s with error code %d",
+			status,
+			errorCode,
+		),
+	)
+	if h.failOnUnhealthyDevice {
+		return fmt.Errorf("%w: %s with error code %d", ErrUnhealthyDevice, status, errorCode)
+	}
+	return nil
+}
+
+// GetDeviceHealth returns the last health status reported by ultra_simple.
+//
+// Returns:
+//
+// The health status, e.g. DeviceHealthGood, or empty if it wasn't reported, and its error code.
+func (h *DefaultHandler) GetDeviceHealth() (status string, errorCode int) {
+	h.deviceInfoMutex.Lock()
+	defer h.deviceInfoMutex.Unlock()
+	return h.deviceInfo.HealthStatus, h.deviceInfo.HealthErrorCode
+}
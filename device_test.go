@@ -0,0 +1,154 @@
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseBannerLine checks the device info fields parsed from the banner lines printed by ultra_simple.
+func TestParseBannerLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected DeviceInfo
+	}{
+		{
+			name:     "serial number",
+			line:     "SLAMTEC LIDAR S/N: 5EB4E9F3C3E09CD4A7E69CF7",
+			expected: DeviceInfo{SerialNumber: "5EB4E9F3C3E09CD4A7E69CF7"},
+		},
+		{name: "sdk version", line: "SDK Version: 2.0.0", expected: DeviceInfo{SDKVersion: "2.0.0"}},
+		{name: "version", line: "Version: 1.12.0", expected: DeviceInfo{SDKVersion: "1.12.0"}},
+		{name: "firmware version", line: "Firmware Ver: 1.29", expected: DeviceInfo{FirmwareVersion: "1.29"}},
+		{name: "firmware long form", line: "firmware version : 1.30", expected: DeviceInfo{FirmwareVersion: "1.30"}},
+		{name: "hardware revision", line: "Hardware Rev: 18", expected: DeviceInfo{HardwareRevision: "18"}},
+		{name: "hardware long form", line: "HARDWARE REVISION: 7", expected: DeviceInfo{HardwareRevision: "7"}},
+		{name: "unrelated line", line: "Ultra simple LIDAR data grabber for SLAMTEC LIDAR."},
+		{name: "empty line", line: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var deviceInfo DeviceInfo
+			parseBannerLine(&deviceInfo, test.line)
+			if deviceInfo.SDKVersion != test.expected.SDKVersion ||
+				deviceInfo.SerialNumber != test.expected.SerialNumber ||
+				deviceInfo.FirmwareVersion != test.expected.FirmwareVersion ||
+				deviceInfo.HardwareRevision != test.expected.HardwareRevision {
+				t.Errorf("expected %+v, got %+v", test.expected, deviceInfo)
+			}
+		})
+	}
+}
+
+// TestParseHealthLine checks the health status and error code parsed from the lines printed by ultra_simple.
+func TestParseHealthLine(t *testing.T) {
+	tests := []struct {
+		name              string
+		line              string
+		expectedStatus    string
+		expectedErrorCode int
+		expectedOk        bool
+	}{
+		{name: "numeric good", line: "SLAMTEC Lidar health status : 0", expectedStatus: DeviceHealthGood, expectedOk: true},
+		{name: "numeric warning", line: "Lidar health status : 1", expectedStatus: DeviceHealthWarning, expectedOk: true},
+		{
+			name:              "numeric error with error code",
+			line:              "SLAMTEC Lidar health status : 2, error code: 32776",
+			expectedStatus:    DeviceHealthError,
+			expectedErrorCode: 32776,
+			expectedOk:        true,
+		},
+		{name: "unknown numeric code", line: "health status : 7", expectedStatus: "7", expectedOk: true},
+		{name: "named status", line: "Device health: good", expectedStatus: DeviceHealthGood, expectedOk: true},
+		{name: "unknown named status", line: "Device health: Degraded", expectedStatus: "Degraded", expectedOk: true},
+		{
+			name:           "internal error",
+			line:           "Error, rplidar internal error detected. Please reboot the device to retry.",
+			expectedStatus: DeviceHealthError,
+			expectedOk:     true,
+		},
+		{name: "unrelated line", line: "Firmware Ver: 1.29"},
+		{name: "measure line", line: "S 0.00 500.00 47"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, errorCode, ok := parseHealthLine(test.line)
+			if status != test.expectedStatus || errorCode != test.expectedErrorCode || ok != test.expectedOk {
+				t.Errorf(
+					"expected (%q, %d, %t), got (%q, %d, %t)",
+					test.expectedStatus,
+					test.expectedErrorCode,
+					test.expectedOk,
+					status,
+					errorCode,
+					ok,
+				)
+			}
+		})
+	}
+}
+
+// TestGetDeviceInfo checks that the banner lines printed before the first measure are recorded and parsed.
+func TestGetDeviceInfo(t *testing.T) {
+	h := newTestLineHandler(t)
+	bannerLines := []string{
+		"SLAMTEC LIDAR S/N: 5EB4E9F3C3E09CD4A7E69CF7",
+		"SDK Version: 2.0.0",
+		"Firmware Ver: 1.29",
+		"Hardware Rev: 18",
+		"SLAMTEC Lidar health status : 0",
+	}
+	for _, line := range append(bannerLines, "S 0.00 500.00 47", "Firmware Ver: 9.99") {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+
+	deviceInfo := h.GetDeviceInfo()
+	if len(deviceInfo.Lines) != len(bannerLines) {
+		t.Fatalf("expected the banner lines %q, got %q", bannerLines, deviceInfo.Lines)
+	}
+	for i, line := range bannerLines {
+		if deviceInfo.Lines[i] != line {
+			t.Errorf("expected the banner line %q, got %q", line, deviceInfo.Lines[i])
+		}
+	}
+	if deviceInfo.SerialNumber != "5EB4E9F3C3E09CD4A7E69CF7" || deviceInfo.SDKVersion != "2.0.0" ||
+		deviceInfo.FirmwareVersion != "1.29" || deviceInfo.HardwareRevision != "18" {
+		t.Errorf("unexpected device info: %+v", deviceInfo)
+	}
+	if deviceInfo.HealthStatus != DeviceHealthGood || deviceInfo.HealthErrorCode != 0 {
+		t.Errorf(
+			"expected the health status %q, got %q with error code %d",
+			DeviceHealthGood,
+			deviceInfo.HealthStatus,
+			deviceInfo.HealthErrorCode,
+		)
+	}
+
+	// Check that the returned lines are a copy
+	deviceInfo.Lines[0] = ""
+	if h.GetDeviceInfo().Lines[0] != bannerLines[0] {
+		t.Error("expected the device info lines to be a copy")
+	}
+}
+
+// TestCheckDeviceHealthFailOnUnhealthyDevice checks that an unhealthy device only fails the run when requested.
+func TestCheckDeviceHealthFailOnUnhealthyDevice(t *testing.T) {
+	line := "SLAMTEC Lidar health status : 2, error code: 32776"
+
+	h := newTestLineHandler(t)
+	if err := h.handleStdoutLine(line); err != nil {
+		t.Errorf("expected no error without WithFailOnUnhealthyDevice, got %v", err)
+	}
+	if status, errorCode := h.GetDeviceHealth(); status != DeviceHealthError || errorCode != 32776 {
+		t.Errorf("expected the health status %q with error code 32776, got %q with %d", DeviceHealthError, status, errorCode)
+	}
+
+	h = newTestLineHandler(t, WithFailOnUnhealthyDevice())
+	if err := h.handleStdoutLine(line); !errors.Is(err, ErrUnhealthyDevice) {
+		t.Errorf("expected %v, got %v", ErrUnhealthyDevice, err)
+	}
+}
@@ -1,5 +1,9 @@
 package go_rplidar_sdk_handler
 
+import (
+	"math"
+)
+
 type (
 	// CardinalDirection is an enum to represent the different cardinal directions that the RPLiDAR can face.
 	CardinalDirection uint8
@@ -66,7 +70,7 @@ var (
 		CardinalDirectionNorthNorthwest: 337.5,
 	}
 
-	// CardinalDirections is a slice of all valid CardinalDirection values
+	// CardinalDirections is a slice of all valid CardinalDirection values, the 16 points of the compass rose
 	CardinalDirections = []CardinalDirection{
 		CardinalDirectionNorth,
 		CardinalDirectionWest,
@@ -85,6 +89,19 @@ var (
 		CardinalDirectionSouthSouthwest,
 		CardinalDirectionSouthSoutheast,
 	}
+
+	// PrimaryCardinalDirections is a slice of the 8 primary CardinalDirection values, the cardinal and intercardinal
+	// points of the compass rose
+	PrimaryCardinalDirections = []CardinalDirection{
+		CardinalDirectionNorth,
+		CardinalDirectionNortheast,
+		CardinalDirectionEast,
+		CardinalDirectionSoutheast,
+		CardinalDirectionSouth,
+		CardinalDirectionSouthwest,
+		CardinalDirectionWest,
+		CardinalDirectionNorthwest,
+	}
 )
 
 // String returns the string representation of the CardinalDirection
@@ -104,3 +121,182 @@ func (r CardinalDirection) String() string {
 func (r CardinalDirection) Angle() float64 {
 	return CardinalDirectionAngles[r]
 }
+
+type (
+	// SubscriberOverflowPolicy is an enum to represent what to do when a subscriber channel is full.
+	SubscriberOverflowPolicy uint8
+)
+
+const (
+	SubscriberOverflowPolicyNil SubscriberOverflowPolicy = iota
+	SubscriberOverflowPolicyDropNewest
+	SubscriberOverflowPolicyDropOldest
+)
+
+var (
+	// SubscriberOverflowPolicyNames maps a given SubscriberOverflowPolicy to its string name
+	SubscriberOverflowPolicyNames = map[SubscriberOverflowPolicy]string{
+		SubscriberOverflowPolicyDropNewest: "drop-newest",
+		SubscriberOverflowPolicyDropOldest: "drop-oldest",
+	}
+)
+
+// String returns the string representation of the SubscriberOverflowPolicy
+//
+// Returns:
+//
+// The string representation of the SubscriberOverflowPolicy enum
+func (s SubscriberOverflowPolicy) String() string {
+	return SubscriberOverflowPolicyNames[s]
+}
+
+// IsValid checks if the CardinalDirection is one of the defined cardinal directions
+//
+// Returns:
+//
+// True if the CardinalDirection has a defined angle, false otherwise
+func (r CardinalDirection) IsValid() bool {
+	_, ok := CardinalDirectionAngles[r]
+	return ok
+}
+
+// AngleToNearestDirection maps an angle to the closest of the defined cardinal directions, handling the wrap-around
+// near 0 and 360 degrees.
+//
+// Parameters:
+//
+// angle: The angle in degrees.
+//
+// Returns:
+//
+// The closest CardinalDirection to the given angle.
+func AngleToNearestDirection(angle float64) CardinalDirection {
+	// Normalize the angle to be within [0, 360)
+	angle = math.Mod(angle, 360.0)
+	if angle < 0 {
+		angle += 360.0
+	}
+
+	nearestDirection := CardinalDirectionNil
+	nearestDifference := math.Inf(1)
+	for _, direction := range CardinalDirections {
+		// Get the circular difference between the angles
+		difference := math.Abs(angle - direction.Angle())
+		difference = math.Min(difference, 360.0-difference)
+		if difference < nearestDifference {
+			nearestDirection = direction
+			nearestDifference = difference
+		}
+	}
+	return nearestDirection
+}
+
+type (
+	// MotionKind is an enum to represent how the distance of an angle changed between two consecutive scans.
+	MotionKind uint8
+)
+
+const (
+	MotionKindNil MotionKind = iota
+	MotionKindApproaching
+	MotionKindReceding
+)
+
+var (
+	// MotionKindNames maps a given MotionKind to its string name
+	MotionKindNames = map[MotionKind]string{
+		MotionKindApproaching: "approaching",
+		MotionKindReceding:    "receding",
+	}
+)
+
+// String returns the string representation of the MotionKind
+//
+// Returns:
+//
+// The string representation of the MotionKind enum
+func (m MotionKind) String() string {
+	return MotionKindNames[m]
+}
+
+type (
+	// DistanceUnit is an enum to represent the units a distance in millimeters can be converted to.
+	DistanceUnit uint8
+)
+
+const (
+	DistanceUnitNil DistanceUnit = iota
+	DistanceUnitMillimeters
+	DistanceUnitCentimeters
+	DistanceUnitMeters
+)
+
+var (
+	// DistanceUnitNames maps a given DistanceUnit to its string name
+	DistanceUnitNames = map[DistanceUnit]string{
+		DistanceUnitMillimeters: "mm",
+		DistanceUnitCentimeters: "cm",
+		DistanceUnitMeters:      "m",
+	}
+
+	// DistanceUnitMillimetersPerUnit maps a given DistanceUnit to the number of millimeters of one unit
+	DistanceUnitMillimetersPerUnit = map[DistanceUnit]float64{
+		DistanceUnitMillimeters: 1.0,
+		DistanceUnitCentimeters: 10.0,
+		DistanceUnitMeters:      1000.0,
+	}
+)
+
+// String returns the string representation of the DistanceUnit
+//
+// Returns:
+//
+// The string representation of the DistanceUnit enum
+func (d DistanceUnit) String() string {
+	return DistanceUnitNames[d]
+}
+
+// IsValid checks if the DistanceUnit is one of the defined distance units
+//
+// Returns:
+//
+// True if the DistanceUnit has a defined number of millimeters per unit, false otherwise
+func (d DistanceUnit) IsValid() bool {
+	_, ok := DistanceUnitMillimetersPerUnit[d]
+	return ok
+}
+
+// FromMillimeters converts a distance in millimeters, as reported by the RPLiDAR, to the DistanceUnit.
+//
+// Parameters:
+//
+// distance: The distance in millimeters.
+//
+// Returns:
+//
+// The distance in the DistanceUnit, or in millimeters if the DistanceUnit is not valid.
+func (d DistanceUnit) FromMillimeters(distance float64) float64 {
+	millimetersPerUnit, ok := DistanceUnitMillimetersPerUnit[d]
+	if !ok {
+		return distance
+	}
+	return distance / millimetersPerUnit
+}
+
+// ToMillimeters converts a distance in the DistanceUnit to millimeters, such as a threshold to compare with the
+// measures.
+//
+// Parameters:
+//
+// distance: The distance in the DistanceUnit.
+//
+// Returns:
+//
+// The distance in millimeters, or the same distance if the DistanceUnit is not valid.
+func (d DistanceUnit) ToMillimeters(distance float64) float64 {
+	millimetersPerUnit, ok := DistanceUnitMillimetersPerUnit[d]
+	if !ok {
+		return distance
+	}
+	return distance * millimetersPerUnit
+}
@@ -5,14 +5,63 @@ import (
 )
 
 var (
-	ErrNilHandler              = errors.New("handler cannot be nil")
-	ErrNilLineHandler          = errors.New("line handler cannot be nil")
-	ErrHandlerAlreadyRunning   = errors.New("handler is already running")
-	ErrEmptyUltraSimplePath    = errors.New("ultra_simple path cannot be empty")
-	ErrInvalidMaxDistanceLimit = errors.New("max distance limit must be greater than zero")
-	ErrAngleWidthMustBeOdd     = errors.New("angle width must be odd")
-	ErrAngleWidthTooSmall      = errors.New("angle width must be greater than 0")
-	ErrAngleWidthTooLarge      = errors.New("angle width must be less than 360 degrees")
-	ErrInvalidMeasuresChannelSize = errors.New("measures channel size must be greater than 0")
-	ErrHandlerIsNotRunning    = errors.New("handler is not running")
+	ErrNilHandler                       = errors.New("handler cannot be nil")
+	ErrNilLineHandler                   = errors.New("line handler cannot be nil")
+	ErrHandlerAlreadyRunning            = errors.New("handler is already running")
+	ErrEmptyUltraSimplePath             = errors.New("ultra_simple path cannot be empty")
+	ErrInvalidMaxDistanceLimit          = errors.New("max distance limit must be greater than zero")
+	ErrAngleWidthMustBeOdd              = errors.New("angle width must be odd")
+	ErrAngleWidthTooSmall               = errors.New("angle width must be greater than 0")
+	ErrAngleWidthTooLarge               = errors.New("angle width must be less than 360 degrees")
+	ErrInvalidMeasuresChannelSize       = errors.New("measures channel size must be greater than 0")
+	ErrHandlerIsNotRunning              = errors.New("handler is not running")
+	ErrInvalidSubscriberBufferSize      = errors.New("subscriber buffer size must be greater than 0")
+	ErrInvalidSubscriberOverflowPolicy  = errors.New("invalid subscriber overflow policy")
+	ErrInvalidIgnoreFirstStdoutMessages = errors.New("number of initial stdout messages to ignore cannot be negative")
+	ErrDuplicateUltraSimpleArgument     = errors.New("extra argument duplicates a managed ultra_simple argument")
+	ErrInvalidRetryPolicy               = errors.New("invalid retry policy")
+	ErrMaxRetryAttemptsReached          = errors.New("max number of retry attempts reached")
+	ErrEmptyReplayPath                  = errors.New("replay path cannot be empty")
+	ErrInvalidRotationPeriod            = errors.New("rotation period cannot be negative")
+	ErrInvalidCloseTimeout              = errors.New("close timeout must be greater than zero")
+	ErrProcessExited                    = errors.New("ultra_simple process exited unexpectedly")
+	ErrInvalidDirection                 = errors.New("invalid cardinal direction")
+	ErrInvalidAngle                     = errors.New("angle must be in [0, 360)")
+	ErrNoValidMeasures                  = errors.New("no valid measures within the angle window")
+	ErrInvalidBucketsPerDegree          = errors.New("buckets per degree must be between 1 and 100")
+	ErrInvalidMeasureTTL                = errors.New("measure TTL cannot be negative")
+	ErrInvalidMinDistanceLimit          = errors.New("min distance limit must be non-negative and less than the max distance limit")
+	ErrInvalidDownsampleStep            = errors.New("downsample step must be between 1 and 360")
+	ErrNilReader                        = errors.New("reader cannot be nil")
+	ErrDataTimeout                      = errors.New("no measure received within the data timeout")
+	ErrInvalidDataTimeout               = errors.New("data timeout cannot be negative")
+	ErrEmptyHandlerName                 = errors.New("handler name cannot be empty")
+	ErrDuplicateHandlerName             = errors.New("handler name is already registered")
+	ErrManagerAlreadyRunning            = errors.New("manager is already running")
+	ErrManagerIsNotRunning              = errors.New("manager is not running")
+	ErrInvalidSectorWidth               = errors.New("sector width must be between 1 and 360 degrees")
+	ErrInvalidThreshold                 = errors.New("threshold must be greater than zero")
+	ErrInvalidBufferSizes               = errors.New("buffer sizes must be greater than zero and the initial size cannot exceed the max size")
+	ErrInvalidMaxLineLength             = errors.New("max line length cannot be negative nor reach the max buffer size")
+	ErrMeasureFieldCount                = errors.New("unexpected number of measure fields")
+	ErrParseAngle                       = errors.New("failed to parse angle")
+	ErrParseDistance                    = errors.New("failed to parse distance")
+	ErrParseQuality                     = errors.New("failed to parse quality")
+	ErrRunFinishedBeforeStart           = errors.New("run finished before the measure source started")
+	ErrEmptyPort                        = errors.New("port cannot be empty")
+	ErrPortNotFound                     = errors.New("port device not found")
+	ErrEmptyBaudRateCandidates          = errors.New("baud rate candidates cannot be empty")
+	ErrInvalidDetectionTimeout          = errors.New("detection timeout must be greater than zero")
+	ErrBaudRateNotDetected              = errors.New("no baud rate candidate yielded measures")
+	ErrInvalidParserConfig              = errors.New("invalid parser config")
+	ErrUnhealthyDevice                  = errors.New("device health is not good")
+	ErrInvalidSmoothingAlpha            = errors.New("smoothing alpha must be in (0, 1]")
+	ErrInvalidScanHistorySize           = errors.New("scan history size cannot be negative")
+	ErrInvalidAngleRange                = errors.New("angle range bounds must be in [0, 360)")
+	ErrInvalidBaudRate                  = errors.New("baud rate must be greater than zero")
+	ErrInvalidQualityScale              = errors.New("quality scale must be greater than zero")
+	ErrHandlerClosed                    = errors.New("handler is closed")
+	ErrInvalidParserWorkers             = errors.New("parser workers cannot be negative")
+	ErrMeasurePoolUnsupported           = errors.New("measure pool can't be used with the accumulated measures or a finer angular resolution")
+	ErrInvalidHealthThresholds          = errors.New("health max data age must be greater than zero, min scan frequency cannot be negative and min coverage must be in [0, 1]")
 )
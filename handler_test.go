@@ -0,0 +1,107 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	goconcurrentlogger "github.com/ralvarezdev/go-concurrent-logger"
+)
+
+// testWaitTimeout is the time to wait for the handler to process the lines written in the tests
+const testWaitTimeout = 5 * time.Second
+
+type (
+	// nopLoggerProducer is a logger producer that discards every message, so the handler internals can be tested
+	// without running a logger.
+	nopLoggerProducer struct{}
+
+	// nopLogger is a logger that creates nopLoggerProducer instances.
+	nopLogger struct{}
+)
+
+func (nopLoggerProducer) Log(string, goconcurrentlogger.Category) {}
+func (nopLoggerProducer) Info(string)                             {}
+func (nopLoggerProducer) Error(error)                             {}
+func (nopLoggerProducer) Warning(string)                          {}
+func (nopLoggerProducer) Debug(string)                            {}
+func (nopLoggerProducer) Close()                                  {}
+func (nopLoggerProducer) IsClosed() bool                          { return false }
+func (nopLoggerProducer) Tag() string                             { return "" }
+func (nopLoggerProducer) IsDebug() bool                           { return false }
+
+func (nopLogger) NewProducer(string, bool) (goconcurrentlogger.LoggerProducer, error) {
+	return nopLoggerProducer{}, nil
+}
+func (nopLogger) ChangeFilePath(string) error              { return nil }
+func (nopLogger) Run(context.Context, func()) error        { return nil }
+func (nopLogger) IsRunning() bool                          { return true }
+func (nopLogger) IsClosed() bool                           { return false }
+func (nopLogger) WaitUntilReady(ctx context.Context) error { return nil }
+
+// newTestReaderHandler creates a handler that parses the lines read from the given reader.
+func newTestReaderHandler(tb testing.TB, r io.Reader, options ...Option) *DefaultHandler {
+	tb.Helper()
+	h, err := NewReaderHandler(r, false, 0, 0, nopLogger{}, 10000, 1024, false, options...)
+	if err != nil {
+		tb.Fatalf("failed to create the handler: %v", err)
+	}
+	return h
+}
+
+// newTestLineHandler creates a handler whose lines are handled directly by the test, without running it.
+func newTestLineHandler(tb testing.TB, options ...Option) *DefaultHandler {
+	tb.Helper()
+	h := newTestReaderHandler(tb, io.MultiReader(), options...)
+	h.handlerLoggerProducer = nopLoggerProducer{}
+	h.resetRunState()
+	return h
+}
+
+// runTestHandler runs the handler in the background until the test finishes, and waits until it's running.
+func runTestHandler(tb testing.TB, h *DefaultHandler) {
+	tb.Helper()
+	ctx, cancelFn := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.Run(ctx, cancelFn)
+	}()
+	tb.Cleanup(func() {
+		cancelFn()
+		<-errCh
+	})
+	waitFor(tb, "the handler to run", h.IsRunning)
+}
+
+// waitFor waits until the condition is true, failing the test after testWaitTimeout.
+func waitFor(tb testing.TB, description string, condition func() bool) {
+	tb.Helper()
+	deadline := time.Now().Add(testWaitTimeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			tb.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// testScanLines returns the plain ultra_simple lines of the given number of rotations, with one measure every step degrees
+// and the sync bit on the first measure of each rotation.
+func testScanLines(rotations int, step int) []string {
+	lines := make([]string, 0, rotations*360/step)
+	for rotation := 0; rotation < rotations; rotation++ {
+		for angle := 0; angle < 360; angle += step {
+			syncBit := ""
+			if angle == 0 {
+				syncBit = SyncBitCharacter + " "
+			}
+			lines = append(
+				lines,
+				fmt.Sprintf("%s%d.50 %d.00 %d", syncBit, angle, 500+rotation*10+angle, 10+angle%40),
+			)
+		}
+	}
+	return lines
+}
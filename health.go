@@ -0,0 +1,50 @@
+package go_rplidar_sdk_handler
+
+import (
+	"fmt"
+	"time"
+)
+
+// Healthy checks if the handler is in a good state, composing the running state, the health reported by the
+// RPLiDAR, the time since the last parsed measure, the scan frequency and the coverage of the current scan against the
+// thresholds set with WithHealthThresholds. It's meant to back a readiness or liveness probe.
+//
+// Returns:
+//
+// True if the handler is healthy, or false and a human-readable reason of the first failed check, e.g. "no data for
+// 2s" or "coverage 12%".
+func (h *DefaultHandler) Healthy() (bool, string) {
+	// Check if the handler is running
+	if !h.IsRunning() {
+		return false, "not running"
+	}
+
+	// Check if the RPLiDAR reported an error health status
+	if status, errorCode := h.GetDeviceHealth(); status == DeviceHealthError {
+		return false, fmt.Sprintf("device health %s with error code %d", status, errorCode)
+	}
+
+	// Check if a measure was parsed recently
+	lastMeasureAt := h.lastMeasureAt.Load()
+	if lastMeasureAt == 0 {
+		return false, "no data received"
+	}
+	if age := time.Since(time.Unix(0, lastMeasureAt)); age > h.healthMaxDataAge {
+		return false, fmt.Sprintf("no data for %s", age.Round(100*time.Millisecond))
+	}
+
+	// Check if the RPLiDAR is spinning at the expected scan frequency
+	if h.healthMinScanFrequency > 0 {
+		if frequency := h.GetScanFrequency(); frequency < h.healthMinScanFrequency {
+			return false, fmt.Sprintf("scan frequency %.1f Hz", frequency)
+		}
+	}
+
+	// Check if the current scan covers enough angles
+	if h.healthMinCoverage > 0 {
+		if coverage := h.CoverageRatio(); coverage < h.healthMinCoverage {
+			return false, fmt.Sprintf("coverage %.0f%%", coverage*100)
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,83 @@
+package go_rplidar_sdk_handler
+
+// recordScanHistory pushes a snapshot of the completed scan to the scan history ring, overwriting the oldest scan once
+// it's full.
+func (h *DefaultHandler) recordScanHistory() {
+	// Check if the scan history is disabled
+	if h.scanHistorySize == 0 {
+		return
+	}
+
+	// Capture the completed scan
+	scan := h.Snapshot()
+
+	h.scanHistoryMutex.Lock()
+	defer h.scanHistoryMutex.Unlock()
+	if h.scanHistory == nil {
+		h.scanHistory = make([]*Scan, h.scanHistorySize)
+	}
+	h.scanHistory[h.scanHistoryIndex] = scan
+	h.scanHistoryIndex = (h.scanHistoryIndex + 1) % h.scanHistorySize
+	if h.scanHistoryCount < h.scanHistorySize {
+		h.scanHistoryCount++
+	}
+}
+
+// resetScanHistory clears the scan history of the previous run.
+func (h *DefaultHandler) resetScanHistory() {
+	h.scanHistoryMutex.Lock()
+	defer h.scanHistoryMutex.Unlock()
+	h.scanHistory = nil
+	h.scanHistoryIndex = 0
+	h.scanHistoryCount = 0
+}
+
+// GetScanHistory returns the most recent complete scans, captured each time the RPLiDAR completes a full rotation.
+//
+// Returns:
+//
+// The most recent scans from oldest to newest, or nil if the scan history is disabled or no rotation has completed.
+func (h *DefaultHandler) GetScanHistory() []*Scan {
+	h.scanHistoryMutex.Lock()
+	defer h.scanHistoryMutex.Unlock()
+
+	// Check if there are scans
+	if h.scanHistoryCount == 0 {
+		return nil
+	}
+
+	scans := make([]*Scan, 0, h.scanHistoryCount)
+	oldestIndex := (h.scanHistoryIndex - h.scanHistoryCount + h.scanHistorySize) % h.scanHistorySize
+	for offset := range h.scanHistoryCount {
+		scans = append(scans, h.scanHistory[(oldestIndex+offset)%h.scanHistorySize])
+	}
+	return scans
+}
+
+// DetectMotion compares the latest two scans of the scan history and reports the angles where the distance changed by
+// more than the threshold.
+//
+// Parameters:
+//
+// distThresholdMm: The minimum distance change in millimeters to report.
+//
+// Returns:
+//
+// The motion events sorted by angle, or nil if there are less than two scans in the scan history or the threshold is
+// not greater than zero.
+func (h *DefaultHandler) DetectMotion(distThresholdMm float64) []MotionEvent {
+	// Check if there are two scans to compare
+	scans := h.GetScanHistory()
+	if len(scans) < 2 {
+		return nil
+	}
+
+	previous := scans[len(scans)-2]
+	current := scans[len(scans)-1]
+	return DetectMotion(
+		&previous.measures,
+		&current.measures,
+		current.GetMaxDistanceLimit(),
+		distThresholdMm,
+	)
+}
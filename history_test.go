@@ -0,0 +1,89 @@
+package go_rplidar_sdk_handler
+
+import (
+	"testing"
+)
+
+// scanDistanceAt returns the distance of the measure of the scan at the given angle, or 0 if there's no measure.
+func scanDistanceAt(scan *Scan, angle int) float64 {
+	if measure := scan.GetMeasures()[angle]; measure != nil {
+		return measure.GetDistance()
+	}
+	return 0
+}
+
+// TestGetScanHistory checks that the scan history ring keeps the most recent scans from oldest to newest, before and
+// after it wraps around. The first sync measure completes an empty rotation, so the oldest scan of a partially filled
+// history has no measures.
+func TestGetScanHistory(t *testing.T) {
+	tests := []struct {
+		name              string
+		scanHistorySize   int
+		rotations         int
+		expectedDistances []float64
+	}{
+		{name: "disabled", scanHistorySize: 0, rotations: 3},
+		{name: "no rotation completed", scanHistorySize: 3, rotations: 0},
+		{name: "partially filled", scanHistorySize: 4, rotations: 2, expectedDistances: []float64{0, 590}},
+		{name: "full", scanHistorySize: 3, rotations: 4, expectedDistances: []float64{590, 600, 610}},
+		{name: "wrapped", scanHistorySize: 3, rotations: 6, expectedDistances: []float64{610, 620, 630}},
+		{name: "single scan", scanHistorySize: 1, rotations: 6, expectedDistances: []float64{630}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := newTestLineHandler(t, WithScanHistorySize(test.scanHistorySize))
+			for _, line := range testScanLines(test.rotations, 30) {
+				if err := h.handleStdoutLine(line); err != nil {
+					t.Fatalf("failed to handle the line %q: %v", line, err)
+				}
+			}
+
+			scans := h.GetScanHistory()
+			if len(scans) != len(test.expectedDistances) {
+				t.Fatalf("expected %d scans, got %d", len(test.expectedDistances), len(scans))
+			}
+			for i, expectedDistance := range test.expectedDistances {
+				if distance := scanDistanceAt(scans[i], 90); distance != expectedDistance {
+					t.Errorf("expected the distance %f at 90 degrees of the scan %d, got %f", expectedDistance, i, distance)
+				}
+			}
+		})
+	}
+}
+
+// TestDetectMotion checks the motion events between the latest two scans of the scan history.
+func TestDetectMotion(t *testing.T) {
+	h := newTestLineHandler(t, WithScanHistorySize(3))
+
+	// Check that a single scan reports no motion
+	for _, line := range testScanLines(2, 30) {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+	if events := h.DetectMotion(5); events != nil {
+		t.Errorf("expected no motion events with a single scan, got %v", events)
+	}
+
+	// Move the obstacle at 90 degrees closer in the next rotation
+	for _, line := range []string{"S 0.00 520.00 10", "30.00 550.00 40", "60.00 580.00 20", "90.00 400.00 20"} {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+	for _, line := range testScanLines(1, 30)[:1] {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+
+	events := h.DetectMotion(20)
+	if len(events) != 1 {
+		t.Fatalf("expected a single motion event, got %v", events)
+	}
+	if event := events[0]; event.Angle != 90 || event.Kind != MotionKindApproaching ||
+		event.PreviousDistance != 600 || event.CurrentDistance != 400 {
+		t.Errorf("unexpected motion event: %+v", event)
+	}
+}
@@ -0,0 +1,95 @@
+package go_rplidar_sdk_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// ScanHTTPHandler returns an HTTP handler that responds with the current scan of the given handler, as the JSON encoded
+// array of 360 measures indexed by angle, or as a SVG polar plot of its points if the format query parameter is svg.
+//
+// Parameters:
+//
+// h: The RPLiDAR handler to read the scan from.
+//
+// Returns:
+//
+// The HTTP handler function, which can be registered on any mux.
+func ScanHTTPHandler(h Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Check if the SVG format was requested
+		if r.URL.Query().Get(ScanHTTPFormatQueryParameter) == ScanHTTPSVGFormat {
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte(pointCloudSVG(handlerPointCloud(h))))
+			return
+		}
+
+		// Encode the scan as JSON
+		body, err := json.Marshal(h.GetMeasures())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+// pointCloudSVG draws the given points as a SVG polar plot centered on the RPLiDAR, with the forward direction up.
+//
+// Parameters:
+//
+// points: A slice of (x, y) points in millimeters.
+//
+// Returns:
+//
+// The SVG document.
+func pointCloudSVG(points [][2]float64) string {
+	// Scale the plot to the farthest point
+	radius := 1.0
+	for _, point := range points {
+		radius = math.Max(radius, math.Hypot(point[0], point[1]))
+	}
+	scale := float64(ScanSVGSize) / 2 / radius
+	center := float64(ScanSVGSize) / 2
+
+	var builder strings.Builder
+	builder.WriteString(
+		fmt.Sprintf(
+			`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+			ScanSVGSize,
+			ScanSVGSize,
+			ScanSVGSize,
+			ScanSVGSize,
+		),
+	)
+	builder.WriteString(
+		fmt.Sprintf(
+			`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="none" stroke="#ccc"/>`,
+			center,
+			center,
+			center,
+		),
+	)
+	builder.WriteString(
+		fmt.Sprintf(
+			`<circle cx="%.1f" cy="%.1f" r="4" fill="red"/>`,
+			center,
+			center,
+		),
+	)
+	for _, point := range points {
+		builder.WriteString(
+			fmt.Sprintf(
+				`<circle cx="%.1f" cy="%.1f" r="2" fill="black"/>`,
+				center+point[0]*scale,
+				center-point[1]*scale,
+			),
+		)
+	}
+	builder.WriteString("</svg>")
+	return builder.String()
+}
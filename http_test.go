@@ -0,0 +1,73 @@
+package go_rplidar_sdk_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestScanHTTPHandler checks the JSON and SVG responses of the scan HTTP handler.
+func TestScanHTTPHandler(t *testing.T) {
+	mock := newTestMock(t, 1000, 0, 90)
+	expectedJSON, err := json.Marshal(mock.GetMeasures())
+	if err != nil {
+		t.Fatalf("failed to encode the scan: %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		target              string
+		expectedContentType string
+		checkBody           func(t *testing.T, body []byte)
+	}{
+		{
+			name:                "JSON",
+			target:              "/scan",
+			expectedContentType: "application/json",
+			checkBody: func(t *testing.T, body []byte) {
+				if !bytes.Equal(body, expectedJSON) {
+					t.Errorf("expected the body %s, got %s", expectedJSON, body)
+				}
+			},
+		},
+		{
+			name:                "SVG",
+			target:              "/scan?" + ScanHTTPFormatQueryParameter + "=" + ScanHTTPSVGFormat,
+			expectedContentType: "image/svg+xml",
+			checkBody: func(t *testing.T, body []byte) {
+				svg := string(body)
+				if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+					t.Fatalf("expected a SVG document, got %s", svg)
+				}
+
+				// Check the points ahead and to the right of the RPLiDAR, scaled to the farthest one
+				for _, point := range []string{
+					`<circle cx="300.0" cy="0.0" r="2" fill="black"/>`,
+					`<circle cx="600.0" cy="300.0" r="2" fill="black"/>`,
+				} {
+					if !strings.Contains(svg, point) {
+						t.Errorf("expected the point %s in %s", point, svg)
+					}
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ScanHTTPHandler(mock)(recorder, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("expected the status %d, got %d", http.StatusOK, recorder.Code)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != test.expectedContentType {
+				t.Errorf("expected the content type %s, got %s", test.expectedContentType, contentType)
+			}
+			test.checkBody(t, recorder.Body.Bytes())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package go_rplidar_sdk_handler
 
 import (
 	"context"
+	"time"
 )
 
 type (
@@ -30,4 +31,56 @@ type (
 			width int,
 		) (map[CardinalDirection]float64, error)
 	}
+
+	// Subscriber is the optional interface of the handlers that can stream the measures to several consumers
+	Subscriber interface {
+		Subscribe(ctx context.Context) (<-chan *Measure, error)
+	}
+
+	// RotationNotifier is the optional interface of the handlers that report each completed full rotation
+	RotationNotifier interface {
+		RotationEvents() <-chan RotationCompleted
+		WaitForFirstRotation(ctx context.Context) error
+	}
+
+	// ParseErrorNotifier is the optional interface of the handlers that report the lines that failed to parse
+	ParseErrorNotifier interface {
+		ParseErrors() <-chan error
+	}
+
+	// StatsProvider is the optional interface of the handlers that report the statistics of the scans
+	StatsProvider interface {
+		GetStats() HandlerStats
+		GetScanFrequency() float64
+		GetRotationCount() uint64
+		CoverageRatio() float64
+	}
+
+	// HealthChecker is the optional interface of the handlers that can report whether they're healthy
+	HealthChecker interface {
+		Healthy() (bool, string)
+	}
+
+	// PointCloudProvider is the optional interface of the handlers that can convert their scan to Cartesian points
+	PointCloudProvider interface {
+		GetPointCloud() [][2]float64
+	}
+
+	// ObstacleDetector is the optional interface of the handlers that can look for obstacles in their scan
+	ObstacleDetector interface {
+		GetNearestObstacle() (angle int, distance float64, ok bool)
+		IsObstacleWithin(
+			middleAngle int,
+			width int,
+			maxMm float64,
+		) bool
+	}
+
+	// EventSink is the interface to receive the structured events of a handler, alongside the human-readable logs. Its
+	// methods are called synchronously from the goroutine that reads the measures, so they must not block.
+	EventSink interface {
+		OnRotation(rotationCount uint64, timestamp time.Time)
+		OnParseError(line string, err error)
+		OnProcessExit(exitCode int, err error)
+	}
 )
@@ -0,0 +1,201 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type (
+	// Manager runs several named RPLiDAR handlers together and merges their scans into the robot frame.
+	Manager struct {
+		mutex     sync.RWMutex
+		handlers  map[string]Handler
+		poses     map[string]MountPose
+		isRunning bool
+		cancelFn  context.CancelFunc
+	}
+)
+
+// NewManager creates a new Manager instance without handlers.
+//
+// Returns:
+//
+// A pointer to a Manager instance.
+func NewManager() *Manager {
+	return &Manager{
+		handlers: make(map[string]Handler),
+		poses:    make(map[string]MountPose),
+	}
+}
+
+// Add registers a handler with its mount pose.
+//
+// Parameters:
+//
+// name: The unique name of the handler, e.g. "front".
+// handler: The RPLiDAR handler.
+// pose: The mount pose of the RPLiDAR relative to the robot frame.
+//
+// Returns:
+//
+// An error if the name is empty or already registered, if the handler is nil, or if the manager is running.
+func (m *Manager) Add(name string, handler Handler, pose MountPose) error {
+	// Check if the name is empty
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyHandlerName
+	}
+
+	// Check if the handler is nil
+	if handler == nil {
+		return ErrNilHandler
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Check if the manager is running
+	if m.isRunning {
+		return ErrManagerAlreadyRunning
+	}
+
+	// Check if the name is already registered
+	if _, ok := m.handlers[name]; ok {
+		return ErrDuplicateHandlerName
+	}
+	m.handlers[name] = handler
+	m.poses[name] = pose
+	return nil
+}
+
+// Get returns the handler registered with the given name.
+//
+// Parameters:
+//
+// name: The name of the handler.
+//
+// Returns:
+//
+// The handler, and false if there's no handler with that name.
+func (m *Manager) Get(name string) (Handler, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	handler, ok := m.handlers[name]
+	return handler, ok
+}
+
+// Names returns the names of the registered handlers.
+//
+// Returns:
+//
+// The sorted names of the registered handlers.
+func (m *Manager) Names() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	names := make([]string, 0, len(m.handlers))
+	for name := range m.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs every registered handler until the context is done, Stop is called, or any of them stops, which stops the
+// rest of them too.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+//
+// Returns:
+//
+// The first error returned by a handler, which wraps context.Canceled if the manager was stopped, or an error if the
+// manager is already running.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mutex.Lock()
+
+	// Check if it's already running
+	if m.isRunning {
+		m.mutex.Unlock()
+		return ErrManagerAlreadyRunning
+	}
+
+	// Create the context shared by the handlers
+	runCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
+	m.isRunning = true
+	m.cancelFn = cancelFn
+	handlers := make([]Handler, 0, len(m.handlers))
+	for _, handler := range m.handlers {
+		handlers = append(handlers, handler)
+	}
+	m.mutex.Unlock()
+
+	// Set running to false when all the handlers stop
+	defer func() {
+		m.mutex.Lock()
+		m.isRunning = false
+		m.cancelFn = nil
+		m.mutex.Unlock()
+	}()
+
+	// Run the handlers, stopping all of them when any of them stops
+	g := &errgroup.Group{}
+	for _, handler := range handlers {
+		g.Go(
+			func() error {
+				defer cancelFn()
+				return handler.Run(runCtx, cancelFn)
+			},
+		)
+	}
+	return g.Wait()
+}
+
+// Stop stops every registered handler.
+//
+// Returns:
+//
+// An error if the manager is not running.
+func (m *Manager) Stop() error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// Check if it's running
+	if !m.isRunning {
+		return ErrManagerIsNotRunning
+	}
+	m.cancelFn()
+	return nil
+}
+
+// MergedPointCloud returns the current scans of every registered handler as Cartesian points in the robot frame, after
+// applying the mount pose of each handler.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters in the robot frame, sorted by handler name.
+func (m *Manager) MergedPointCloud() [][2]float64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// Sort the names to keep the order of the points stable
+	names := make([]string, 0, len(m.handlers))
+	for name := range m.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var points [][2]float64
+	for _, name := range names {
+		pose := m.poses[name]
+		for _, point := range handlerPointCloud(m.handlers[name]) {
+			points = append(points, pose.Transform(point))
+		}
+	}
+	return points
+}
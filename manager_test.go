@@ -0,0 +1,170 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// pointTolerance is the tolerance used to compare the transformed points
+const pointTolerance = 1e-9
+
+// newTestMock creates a MockHandler with a valid measure at each of the given angles, all at the given distance.
+func newTestMock(tb testing.TB, distance float64, angles ...int) *MockHandler {
+	tb.Helper()
+	mock, err := NewMockHandler(10000, 1)
+	if err != nil {
+		tb.Fatalf("failed to create the mock: %v", err)
+	}
+	for _, angle := range angles {
+		mock.SetMeasure(angle, distance, 47)
+	}
+	return mock
+}
+
+// TestManagerMergedPointCloud checks that the points of each handler are transformed by its mount pose, and merged in
+// the order of the handler names.
+func TestManagerMergedPointCloud(t *testing.T) {
+	tests := []struct {
+		name           string
+		poses          map[string]MountPose
+		expectedPoints [][2]float64
+	}{
+		{
+			name: "identity poses",
+			poses: map[string]MountPose{
+				"front": {},
+				"rear":  {},
+			},
+			expectedPoints: [][2]float64{{0, 1000}, {0, 1000}},
+		},
+		{
+			name: "offsets",
+			poses: map[string]MountPose{
+				"front": {OffsetX: 50, OffsetY: 100},
+				"rear":  {OffsetX: -50, OffsetY: -100},
+			},
+			expectedPoints: [][2]float64{{50, 1100}, {-50, 900}},
+		},
+		{
+			name: "yaws",
+			poses: map[string]MountPose{
+				"front": {Yaw: 90},
+				"rear":  {OffsetY: -100, Yaw: 180},
+			},
+			expectedPoints: [][2]float64{{1000, 0}, {0, -1100}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManager()
+
+			// Add the handlers out of order, each one with a measure straight ahead
+			for _, name := range []string{"rear", "front"} {
+				if err := m.Add(name, newTestMock(t, 1000, 0), test.poses[name]); err != nil {
+					t.Fatalf("failed to add the handler %s: %v", name, err)
+				}
+			}
+
+			points := m.MergedPointCloud()
+			if len(points) != len(test.expectedPoints) {
+				t.Fatalf("expected %d points, got %d: %v", len(test.expectedPoints), len(points), points)
+			}
+			for i, expected := range test.expectedPoints {
+				if math.Abs(points[i][0]-expected[0]) > pointTolerance ||
+					math.Abs(points[i][1]-expected[1]) > pointTolerance {
+					t.Errorf("expected the point %d to be %v, got %v", i, expected, points[i])
+				}
+			}
+		})
+	}
+}
+
+// TestManagerAdd checks the registration of the handlers and its errors.
+func TestManagerAdd(t *testing.T) {
+	m := NewManager()
+	front := newTestMock(t, 1000, 0)
+	if err := m.Add("front", front, MountPose{}); err != nil {
+		t.Fatalf("failed to add the handler: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		handlerName string
+		handler     Handler
+		expectedErr error
+	}{
+		{name: "empty name", handlerName: " ", handler: newTestMock(t, 1000), expectedErr: ErrEmptyHandlerName},
+		{name: "nil handler", handlerName: "rear", expectedErr: ErrNilHandler},
+		{name: "duplicate name", handlerName: "front", handler: newTestMock(t, 1000), expectedErr: ErrDuplicateHandlerName},
+		{name: "new name", handlerName: "rear", handler: newTestMock(t, 1000)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := m.Add(test.handlerName, test.handler, MountPose{}); !errors.Is(err, test.expectedErr) {
+				t.Errorf("expected %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+
+	if names := m.Names(); !reflect.DeepEqual(names, []string{"front", "rear"}) {
+		t.Errorf("expected the names [front rear], got %v", names)
+	}
+	if handler, ok := m.Get("front"); !ok || handler != front {
+		t.Errorf("expected the front handler, got %v (found: %t)", handler, ok)
+	}
+	if _, ok := m.Get("left"); ok {
+		t.Error("expected no handler named left")
+	}
+}
+
+// TestManagerRunStop checks that Run runs every handler until Stop is called, and the running state checks.
+func TestManagerRunStop(t *testing.T) {
+	m := NewManager()
+	front, rear := newTestMock(t, 1000), newTestMock(t, 1000)
+	for name, handler := range map[string]Handler{"front": front, "rear": rear} {
+		if err := m.Add(name, handler, MountPose{}); err != nil {
+			t.Fatalf("failed to add the handler %s: %v", name, err)
+		}
+	}
+
+	// Check that the manager can't be stopped before running
+	if err := m.Stop(); !errors.Is(err, ErrManagerIsNotRunning) {
+		t.Errorf("expected %v, got %v", ErrManagerIsNotRunning, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Run(context.Background())
+	}()
+	waitFor(t, "the handlers to run", func() bool {
+		return front.IsRunning() && rear.IsRunning()
+	})
+
+	// Check that the manager can't be changed nor run again while running
+	if err := m.Add("left", newTestMock(t, 1000), MountPose{}); !errors.Is(err, ErrManagerAlreadyRunning) {
+		t.Errorf("expected %v, got %v", ErrManagerAlreadyRunning, err)
+	}
+	if err := m.Run(context.Background()); !errors.Is(err, ErrManagerAlreadyRunning) {
+		t.Errorf("expected %v, got %v", ErrManagerAlreadyRunning, err)
+	}
+
+	if err := m.Stop(); err != nil {
+		t.Fatalf("failed to stop the manager: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Errorf("unexpected error returned by Run: %v", err)
+	}
+	if front.IsRunning() || rear.IsRunning() {
+		t.Error("expected the handlers to be stopped")
+	}
+
+	// Check that the manager can't be stopped twice
+	if err := m.Stop(); !errors.Is(err, ErrManagerIsNotRunning) {
+		t.Errorf("expected %v, got %v", ErrManagerIsNotRunning, err)
+	}
+}
@@ -0,0 +1,97 @@
+package go_rplidar_sdk_handler
+
+// Validate checks if the angle range is valid.
+//
+// Returns:
+//
+// An error if any bound of the angle range is not in [0, 360).
+func (a AngleRange) Validate() error {
+	if a.Start < 0 || a.Start >= 360 || a.End < 0 || a.End >= 360 {
+		return ErrInvalidAngleRange
+	}
+	return nil
+}
+
+// Contains checks if the given angle is within the angle range, wrapping around the 0/360 seam.
+//
+// Parameters:
+//
+// angle: The angle in degrees.
+//
+// Returns:
+//
+// True if the angle is within the angle range inclusive, false otherwise.
+func (a AngleRange) Contains(angle float64) bool {
+	// Check if the angle range crosses the 0/360 seam
+	if a.Start > a.End {
+		return angle >= a.Start || angle <= a.End
+	}
+	return angle >= a.Start && angle <= a.End
+}
+
+// isMaskedAngle checks if the given angle is within any of the masked ranges. The measures lock must be held.
+//
+// Parameters:
+//
+// angle: The angle in degrees.
+//
+// Returns:
+//
+// True if the angle is masked, false otherwise.
+func (h *DefaultHandler) isMaskedAngle(angle float64) bool {
+	for _, maskedRange := range h.maskedRanges {
+		if maskedRange.Contains(angle) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMaskedRanges sets the angle ranges to ignore, e.g. the blind spots caused by the mounting of the RPLiDAR. The
+// measures within the masked ranges are dropped, and the ones already stored are removed, so every helper skips them.
+//
+// Parameters:
+//
+// maskedRanges: The angle ranges to ignore, whose angles are the ones after applying the upside down and the angle
+// adjustment transforms, or nil to remove the masking.
+//
+// Returns:
+//
+// An error if any angle range is not valid.
+func (h *DefaultHandler) SetMaskedRanges(maskedRanges []AngleRange) error {
+	// Check if the angle ranges are valid
+	for _, maskedRange := range maskedRanges {
+		if err := maskedRange.Validate(); err != nil {
+			return err
+		}
+	}
+
+	h.measuresMutex.Lock()
+	defer h.measuresMutex.Unlock()
+	h.maskedRanges = append([]AngleRange(nil), maskedRanges...)
+
+	// Remove the stored measures within the masked ranges
+	for index, measure := range h.measures {
+		if measure != nil && h.isMaskedAngle(measure.GetAngle()) {
+			h.measures[index] = nil
+			h.accumulatedMeasures[index] = nil
+		}
+	}
+	for index, measure := range h.fineMeasures {
+		if measure != nil && h.isMaskedAngle(measure.GetAngle()) {
+			h.fineMeasures[index] = nil
+		}
+	}
+	return nil
+}
+
+// GetMaskedRanges returns the angle ranges to ignore.
+//
+// Returns:
+//
+// A copy of the masked angle ranges.
+func (h *DefaultHandler) GetMaskedRanges() []AngleRange {
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+	return append([]AngleRange(nil), h.maskedRanges...)
+}
@@ -0,0 +1,96 @@
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestAngleRangeContains checks the angle ranges inclusive bounds, including the ones that wrap around the 0/360 seam.
+func TestAngleRangeContains(t *testing.T) {
+	tests := []struct {
+		name       string
+		angleRange AngleRange
+		angle      float64
+		expected   bool
+	}{
+		{name: "inside", angleRange: AngleRange{Start: 10, End: 20}, angle: 15, expected: true},
+		{name: "start bound", angleRange: AngleRange{Start: 10, End: 20}, angle: 10, expected: true},
+		{name: "end bound", angleRange: AngleRange{Start: 10, End: 20}, angle: 20, expected: true},
+		{name: "before", angleRange: AngleRange{Start: 10, End: 20}, angle: 9.9, expected: false},
+		{name: "after", angleRange: AngleRange{Start: 10, End: 20}, angle: 20.1, expected: false},
+		{name: "single angle", angleRange: AngleRange{Start: 90, End: 90}, angle: 90, expected: true},
+		{name: "seam before 360", angleRange: AngleRange{Start: 350, End: 10}, angle: 355, expected: true},
+		{name: "seam at 0", angleRange: AngleRange{Start: 350, End: 10}, angle: 0, expected: true},
+		{name: "seam after 0", angleRange: AngleRange{Start: 350, End: 10}, angle: 10, expected: true},
+		{name: "seam outside", angleRange: AngleRange{Start: 350, End: 10}, angle: 180, expected: false},
+		{name: "seam just outside the start", angleRange: AngleRange{Start: 350, End: 10}, angle: 349.9, expected: false},
+		{name: "seam just outside the end", angleRange: AngleRange{Start: 350, End: 10}, angle: 10.1, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if contains := test.angleRange.Contains(test.angle); contains != test.expected {
+				t.Errorf("expected %v to contain %f: %t, got %t", test.angleRange, test.angle, test.expected, contains)
+			}
+		})
+	}
+}
+
+// TestSetMaskedRanges checks that the masked ranges remove the stored measures and drop the new ones, across the
+// 0/360 seam.
+func TestSetMaskedRanges(t *testing.T) {
+	h := newTestLineHandler(t)
+	for _, line := range []string{"350.00 1000.00 47", "5.00 1000.00 47", "90.00 1000.00 47"} {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+
+	if err := h.SetMaskedRanges([]AngleRange{{Start: 345, End: 10}}); err != nil {
+		t.Fatalf("failed to set the masked ranges: %v", err)
+	}
+
+	// Check that the stored measures within the masked range were removed
+	for angle, expected := range map[int]bool{350: false, 5: false, 90: true} {
+		if hasMeasure := h.HasMeasureAt(angle); hasMeasure != expected {
+			t.Errorf("expected a measure at %d degrees: %t, got %t", angle, expected, hasMeasure)
+		}
+	}
+
+	// Check that the new measures within the masked range are dropped
+	for _, line := range []string{"355.00 1000.00 47", "0.00 1000.00 47", "180.00 1000.00 47"} {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+	if h.HasMeasureAt(355) || h.HasMeasureAt(0) || !h.HasMeasureAt(180) {
+		t.Errorf("expected only the measure at 180 degrees to be stored, got %v", h.GetValidMeasures())
+	}
+	if maskedDropped := h.GetStats().MaskedDropped; maskedDropped != 2 {
+		t.Errorf("expected 2 masked measures dropped, got %d", maskedDropped)
+	}
+
+	// Check that removing the masking keeps the new measures again
+	if err := h.SetMaskedRanges(nil); err != nil {
+		t.Fatalf("failed to remove the masked ranges: %v", err)
+	}
+	if err := h.handleStdoutLine("355.00 1000.00 47"); err != nil {
+		t.Fatalf("failed to handle the line: %v", err)
+	}
+	if !h.HasMeasureAt(355) {
+		t.Error("expected a measure at 355 degrees after removing the masking")
+	}
+}
+
+// TestSetMaskedRangesErrors checks that the angle ranges with bounds out of [0, 360) are rejected.
+func TestSetMaskedRangesErrors(t *testing.T) {
+	h := newTestLineHandler(t)
+	for _, angleRange := range []AngleRange{{Start: -1, End: 10}, {Start: 350, End: 360}, {Start: 400, End: 10}} {
+		if err := h.SetMaskedRanges([]AngleRange{angleRange}); !errors.Is(err, ErrInvalidAngleRange) {
+			t.Errorf("expected %v for %v, got %v", ErrInvalidAngleRange, angleRange, err)
+		}
+	}
+	if maskedRanges := h.GetMaskedRanges(); len(maskedRanges) != 0 {
+		t.Errorf("expected no masked ranges after the errors, got %v", maskedRanges)
+	}
+}
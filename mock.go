@@ -0,0 +1,761 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// MockHandler is a Handler implementation with programmable measures, so the code built on top of this package can
+	// be tested without a RPLiDAR device.
+	MockHandler struct {
+		mutex            sync.RWMutex
+		isRunning        atomic.Bool
+		measures         [360]*Measure
+		maxDistanceLimit float64
+		scanFrequency    float64
+		unhealthyReason  string
+		stats            HandlerStats
+		measuresCh       chan *Measure
+		rotationEventsCh chan RotationCompleted
+		parseErrorsCh    chan error
+		subscribers      map[chan *Measure]struct{}
+	}
+)
+
+// Check that MockHandler implements the Handler interface and its optional interfaces
+var (
+	_ Handler            = (*MockHandler)(nil)
+	_ Subscriber         = (*MockHandler)(nil)
+	_ RotationNotifier   = (*MockHandler)(nil)
+	_ ParseErrorNotifier = (*MockHandler)(nil)
+	_ StatsProvider      = (*MockHandler)(nil)
+	_ HealthChecker      = (*MockHandler)(nil)
+	_ PointCloudProvider = (*MockHandler)(nil)
+	_ ObstacleDetector   = (*MockHandler)(nil)
+)
+
+// NewMockHandler creates a new MockHandler instance.
+//
+// Parameters:
+//
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// measuresChSize: Size of the channel to send measures.
+//
+// Returns:
+//
+// A pointer to a MockHandler instance or an error if any parameter is invalid.
+func NewMockHandler(
+	maxDistanceLimit float64,
+	measuresChSize int,
+) (*MockHandler, error) {
+	// Check if the max distance limit is valid
+	if maxDistanceLimit <= 0 {
+		return nil, ErrInvalidMaxDistanceLimit
+	}
+
+	// Check if the measures channel size is valid
+	if measuresChSize <= 0 {
+		return nil, ErrInvalidMeasuresChannelSize
+	}
+
+	return &MockHandler{
+		maxDistanceLimit: maxDistanceLimit,
+		measuresCh:       make(chan *Measure, measuresChSize),
+		rotationEventsCh: make(chan RotationCompleted, RotationEventsChannelSize),
+		parseErrorsCh:    make(chan error, ParseErrorsChannelSize),
+		subscribers:      make(map[chan *Measure]struct{}),
+	}, nil
+}
+
+// Run marks the handler as running until the context is done.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation.
+// cancelFn: Function to cancel the context, unused by the mock.
+//
+// Returns:
+//
+// An error if the handler is already running.
+func (m *MockHandler) Run(ctx context.Context, _ context.CancelFunc) error {
+	if !m.isRunning.CompareAndSwap(false, true) {
+		return ErrHandlerAlreadyRunning
+	}
+	defer m.isRunning.Store(false)
+
+	<-ctx.Done()
+	return nil
+}
+
+// IsRunning checks if the handler is currently running.
+//
+// Returns:
+//
+// True if the handler is running, false otherwise.
+func (m *MockHandler) IsRunning() bool {
+	return m.isRunning.Load()
+}
+
+// WaitUntilReady returns immediately, since the mock is ready as soon as it's running.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (m *MockHandler) WaitUntilReady(ctx context.Context) error {
+	if !m.IsRunning() {
+		return ErrHandlerIsNotRunning
+	}
+	return ctx.Err()
+}
+
+// WaitForFirstRotation returns immediately, since the mock is ready as soon as it's running.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (m *MockHandler) WaitForFirstRotation(ctx context.Context) error {
+	return m.WaitUntilReady(ctx)
+}
+
+// StartSendingMeasures is a no-op for the mock.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (m *MockHandler) StartSendingMeasures() error {
+	if !m.IsRunning() {
+		return ErrHandlerIsNotRunning
+	}
+	return nil
+}
+
+// StopSendingMeasures is a no-op for the mock.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (m *MockHandler) StopSendingMeasures() error {
+	if !m.IsRunning() {
+		return ErrHandlerIsNotRunning
+	}
+	return nil
+}
+
+// GetMeasuresChannel returns the channel through which the measures set with SetMeasure are sent.
+//
+// Returns:
+//
+// A read-only channel of measures, or an error if the handler is not running.
+func (m *MockHandler) GetMeasuresChannel() (<-chan *Measure, error) {
+	if !m.IsRunning() {
+		return nil, ErrHandlerIsNotRunning
+	}
+	return m.measuresCh, nil
+}
+
+// Subscribe returns a new channel that receives the measures set with SetMeasure, which is closed when the context is
+// cancelled.
+//
+// Parameters:
+//
+// ctx: Context to unsubscribe from the measures.
+//
+// Returns:
+//
+// A read-only channel of measures, or an error if the handler is not running.
+func (m *MockHandler) Subscribe(ctx context.Context) (<-chan *Measure, error) {
+	if !m.IsRunning() {
+		return nil, ErrHandlerIsNotRunning
+	}
+
+	// Register the subscriber
+	ch := make(chan *Measure, DefaultSubscriberBufferSize)
+	m.mutex.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.mutex.Unlock()
+
+	// Unsubscribe when the context is cancelled
+	go func() {
+		<-ctx.Done()
+		m.mutex.Lock()
+		delete(m.subscribers, ch)
+		close(ch)
+		m.mutex.Unlock()
+	}()
+	return ch, nil
+}
+
+// RotationEvents returns the channel that receives an event each time CompleteRotation is called.
+//
+// Returns:
+//
+// A read-only channel of rotation completed events.
+func (m *MockHandler) RotationEvents() <-chan RotationCompleted {
+	return m.rotationEventsCh
+}
+
+// ParseErrors returns the channel that receives the errors emitted with EmitParseError.
+//
+// Returns:
+//
+// A read-only channel of parse errors.
+func (m *MockHandler) ParseErrors() <-chan error {
+	return m.parseErrorsCh
+}
+
+// EmitParseError emits a parse error without blocking, and counts it in the stats.
+//
+// Parameters:
+//
+// err: The parse error to emit.
+func (m *MockHandler) EmitParseError(err error) {
+	m.mutex.Lock()
+	m.stats.ParseErrors++
+	m.mutex.Unlock()
+
+	select {
+	case m.parseErrorsCh <- err:
+	default:
+	}
+}
+
+// GetScanFrequency returns the scan frequency set with SetScanFrequency.
+//
+// Returns:
+//
+// The scan frequency in Hz.
+func (m *MockHandler) GetScanFrequency() float64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.scanFrequency
+}
+
+// GetStats returns the stats of the measures set with SetMeasure and the rotations completed with CompleteRotation.
+//
+// Returns:
+//
+// The handler stats.
+func (m *MockHandler) GetStats() HandlerStats {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.stats
+}
+
+// GetRotationCount returns the number of rotations completed with CompleteRotation.
+//
+// Returns:
+//
+// The number of rotations completed.
+func (m *MockHandler) GetRotationCount() uint64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.stats.RotationsCompleted
+}
+
+// GetMeasures returns a copy of the programmed measures.
+//
+// Returns:
+//
+// A copy of the programmed measures.
+func (m *MockHandler) GetMeasures() *[360]*Measure {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	measuresCopy := m.measures
+	return &measuresCopy
+}
+
+// Snapshot captures the programmed measures as a Scan.
+//
+// Returns:
+//
+// A pointer to a Scan instance with a copy of the programmed measures.
+func (m *MockHandler) Snapshot() *Scan {
+	return &Scan{
+		measures:         *m.GetMeasures(),
+		maxDistanceLimit: m.maxDistanceLimit,
+		timestamp:        time.Now(),
+	}
+}
+
+// GetScanResult captures the programmed measures alongside their metadata.
+//
+// Returns:
+//
+// The current scan result.
+func (m *MockHandler) GetScanResult() ScanResult {
+	// Lock the mock for reading
+	m.mutex.RLock()
+	measures := m.measures
+	maxDistanceLimit := m.maxDistanceLimit
+	frequency := m.scanFrequency
+	m.mutex.RUnlock()
+
+	return newScanResult(&measures, maxDistanceLimit, frequency, time.Now())
+}
+
+// GetValidMeasures returns the valid programmed measures.
+//
+// Returns:
+//
+// The valid measures sorted by angle.
+func (m *MockHandler) GetValidMeasures() []*Measure {
+	return GetValidMeasures(m.GetMeasures(), m.maxDistanceLimit)
+}
+
+// ClearMeasures discards the programmed measures.
+func (m *MockHandler) ClearMeasures() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.measures = [360]*Measure{}
+}
+
+// SetMeasure programs the measure of the given angle, and sends it to the measures channel and the subscribers.
+//
+// Parameters:
+//
+// angle: The angle of the measure in degrees, normalized to [0, 360).
+// distance: The distance of the measure in millimeters.
+// quality: The quality of the measure.
+func (m *MockHandler) SetMeasure(angle int, distance float64, quality int) {
+	angle = ((angle % 360) + 360) % 360
+	measure := &Measure{
+		angle:     float64(angle),
+		rawAngle:  float64(angle),
+		distance:  distance,
+		quality:   quality,
+		timestamp: time.Now(),
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.measures[angle] = measure
+	m.stats.LinesRead++
+	m.stats.MeasuresParsed++
+
+	// Send the measure without blocking
+	select {
+	case m.measuresCh <- measure:
+	default:
+	}
+	for ch := range m.subscribers {
+		select {
+		case ch <- measure:
+		default:
+		}
+	}
+}
+
+// SetMeasures programs all the measures at once.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+func (m *MockHandler) SetMeasures(measures *[360]*Measure) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.measures = *measures
+}
+
+// SetScanFrequency programs the scan frequency returned by GetScanFrequency.
+//
+// Parameters:
+//
+// scanFrequency: The scan frequency in Hz.
+func (m *MockHandler) SetScanFrequency(scanFrequency float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.scanFrequency = scanFrequency
+}
+
+// SetUnhealthyReason sets the reason returned by Healthy while the mock is running.
+//
+// Parameters:
+//
+// reason: Reason of the unhealthy state, or empty to report the mock as healthy.
+func (m *MockHandler) SetUnhealthyReason(reason string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.unhealthyReason = reason
+}
+
+// Healthy checks if the mock is running, and if no unhealthy reason is set with SetUnhealthyReason.
+//
+// Returns:
+//
+// True if the mock is healthy, or false and the reason of the unhealthy state.
+func (m *MockHandler) Healthy() (bool, string) {
+	// Check if the mock is running
+	if !m.IsRunning() {
+		return false, "not running"
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.unhealthyReason != "" {
+		return false, m.unhealthyReason
+	}
+	return true, ""
+}
+
+// CompleteRotation emits a rotation completed event without blocking.
+func (m *MockHandler) CompleteRotation() {
+	m.mutex.Lock()
+	m.stats.RotationsCompleted++
+	m.mutex.Unlock()
+
+	select {
+	case m.rotationEventsCh <- RotationCompleted{}:
+	default:
+	}
+}
+
+// GetInterpolatedScan fills the small gaps of the programmed measures by linearly interpolating the nearest valid
+// measures.
+//
+// Parameters:
+//
+// maxGap: The maximum number of consecutive missing angles to fill.
+//
+// Returns:
+//
+// A copy of the programmed measures with the small gaps filled.
+func (m *MockHandler) GetInterpolatedScan(maxGap int) *[360]*Measure {
+	return GetInterpolatedScan(m.GetMeasures(), m.maxDistanceLimit, maxGap)
+}
+
+// HasMeasureAt checks if the given angle of the programmed measures has a valid measure.
+//
+// Parameters:
+//
+// angle: The angle to check in degrees.
+//
+// Returns:
+//
+// True if the angle is within [0, 360) and has a valid measure, false otherwise.
+func (m *MockHandler) HasMeasureAt(angle int) bool {
+	// Check if the angle is valid
+	if angle < 0 || angle >= 360 {
+		return false
+	}
+	return isValidMeasure(m.GetMeasures()[angle], m.maxDistanceLimit)
+}
+
+// CoverageRatio calculates the fraction of the 360 angles of the programmed measures that have a valid measure.
+//
+// Returns:
+//
+// The fraction of angles with a valid measure, in [0, 1].
+func (m *MockHandler) CoverageRatio() float64 {
+	return CoverageRatio(m.GetMeasures(), m.maxDistanceLimit)
+}
+
+// GetPointCloud returns the valid programmed measures as Cartesian points.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func (m *MockHandler) GetPointCloud() [][2]float64 {
+	return pointCloud(m.GetMeasures()[:], m.maxDistanceLimit)
+}
+
+// GetNearestObstacle finds the closest valid programmed measure.
+//
+// Returns:
+//
+// The angle and distance of the closest valid measure, and false if there are no valid measures.
+func (m *MockHandler) GetNearestObstacle() (angle int, distance float64, ok bool) {
+	return GetNearestObstacle(m.GetMeasures(), m.maxDistanceLimit)
+}
+
+// GetFarthestValidDistance finds the valid programmed measure with the most clearance.
+//
+// Returns:
+//
+// The angle and distance of the farthest valid measure, and false if there are no valid measures.
+func (m *MockHandler) GetFarthestValidDistance() (angle int, distance float64, ok bool) {
+	return GetFarthestValidDistance(m.GetMeasures(), m.maxDistanceLimit)
+}
+
+// DetectClusters groups the contiguous valid programmed measures with similar distances.
+//
+// Parameters:
+//
+// maxGapDeg: The maximum angular gap in degrees between two measures of the same cluster.
+// maxDistJumpMm: The maximum distance jump in millimeters between two measures of the same cluster.
+//
+// Returns:
+//
+// The clusters sorted by their start angle.
+func (m *MockHandler) DetectClusters(
+	maxGapDeg int,
+	maxDistJumpMm float64,
+) []Cluster {
+	return DetectClusters(m.GetMeasures(), m.maxDistanceLimit, maxGapDeg, maxDistJumpMm)
+}
+
+// FreeCorridorWidth expands from the heading to both sides of the programmed measures until it hits a measure closer
+// than the clearance.
+//
+// Parameters:
+//
+// heading: The heading angle in degrees.
+// clearanceMm: The clearance in millimeters.
+//
+// Returns:
+//
+// The last clear angles at the left and at the right of the heading, or NoCorridorAngle at both sides if the heading or
+// the clearance is not valid, or if the heading itself is blocked.
+func (m *MockHandler) FreeCorridorWidth(
+	heading int,
+	clearanceMm float64,
+) (leftAngle, rightAngle int) {
+	return FreeCorridorWidth(m.GetMeasures(), m.maxDistanceLimit, heading, clearanceMm)
+}
+
+// GetDownsampledScan reduces the programmed measures to the nearest valid measure of each sector of step degrees.
+//
+// Parameters:
+//
+// step: The width of each sector in degrees.
+//
+// Returns:
+//
+// A slice with the representative of each sector, which is nil if the sector has no valid measures, or an error if the
+// step is not valid.
+func (m *MockHandler) GetDownsampledScan(step int) ([]*Measure, error) {
+	return GetDownsampledScan(m.GetMeasures(), m.maxDistanceLimit, step)
+}
+
+// GetPolarHistogram calculates the obstacle density of each sector of sectorDeg degrees of the programmed measures.
+//
+// Parameters:
+//
+// sectorDeg: The width of each sector in degrees.
+// thresholdMm: The distance in millimeters under which a measure is considered an obstacle.
+//
+// Returns:
+//
+// The obstacle density of each sector in clockwise order starting at 0 degrees, or an error if the sector width or the
+// threshold is not valid.
+func (m *MockHandler) GetPolarHistogram(sectorDeg int, thresholdMm float64) ([]float64, error) {
+	return GetPolarHistogram(m.GetMeasures(), m.maxDistanceLimit, sectorDeg, thresholdMm)
+}
+
+// GetAverageDistanceFromAngle calculates the average distance for a given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the average distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The average distance for the specified angle, or an error if the angle is not valid.
+func (m *MockHandler) GetAverageDistanceFromAngle(
+	middleAngle int,
+	width int,
+) (float64, error) {
+	return GetAverageDistanceFromAngle(m.GetMeasures(), middleAngle, width)
+}
+
+// GetQualityWeightedAverageFromAngle calculates the average distance for a given angle, weighting each distance by its
+// quality.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the average distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The quality-weighted average distance for the specified angle, or an error if the angle is not valid.
+func (m *MockHandler) GetQualityWeightedAverageFromAngle(
+	middleAngle int,
+	width int,
+) (float64, error) {
+	return GetQualityWeightedAverageFromAngle(m.GetMeasures(), middleAngle, width)
+}
+
+// GetDistanceStdDevFromAngle calculates the mean and the sample standard deviation of the distances for a given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the standard deviation for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The mean and the sample standard deviation of the distances for the specified angle, or an error if the angle is not
+// valid.
+func (m *MockHandler) GetDistanceStdDevFromAngle(
+	middleAngle int,
+	width int,
+) (mean, stddev float64, err error) {
+	return GetDistanceStdDevFromAngle(m.GetMeasures(), middleAngle, width)
+}
+
+// GetAverageDistanceInRange calculates the average distance from the start angle to the end angle inclusive.
+//
+// Parameters:
+//
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range, which wraps around the 0/360 seam if it's less than the start angle.
+//
+// Returns:
+//
+// The average distance for the specified range, or an error if any angle is not valid.
+func (m *MockHandler) GetAverageDistanceInRange(
+	startAngle int,
+	endAngle int,
+) (float64, error) {
+	return GetAverageDistanceInRange(m.GetMeasures(), startAngle, endAngle)
+}
+
+// GetMedianDistanceFromAngle calculates the median distance for a given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the median distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The median distance for the specified angle, or an error if the angle is not valid.
+func (m *MockHandler) GetMedianDistanceFromAngle(
+	middleAngle int,
+	width int,
+) (float64, error) {
+	return GetMedianDistanceFromAngle(m.GetMeasures(), middleAngle, width)
+}
+
+// IsObstacleWithin checks if there's a valid measure within the given distance inside a window of angles.
+//
+// Parameters:
+//
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// maxMm: The distance in millimeters within which a measure is an obstacle.
+//
+// Returns:
+//
+// True if a valid measure within the distance is found, or false if there's none or the window is not valid.
+func (m *MockHandler) IsObstacleWithin(
+	middleAngle int,
+	width int,
+	maxMm float64,
+) bool {
+	return IsObstacleWithin(m.GetMeasures(), middleAngle, width, maxMm)
+}
+
+// GetAverageDistanceFromDirection calculates the average distance for a given direction.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction is not valid.
+func (m *MockHandler) GetAverageDistanceFromDirection(
+	width int,
+	direction CardinalDirection,
+) (float64, error) {
+	return GetAverageDistanceFromDirection(m.GetMeasures(), width, direction)
+}
+
+// GetMeasuresInDirection collects the valid measures within the window centered on a given direction.
+//
+// Parameters:
+//
+// direction: The direction to center the window on.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The valid measures within the window, or an error if the direction or the width is not valid.
+func (m *MockHandler) GetMeasuresInDirection(
+	direction CardinalDirection,
+	width int,
+) ([]*Measure, error) {
+	return GetMeasuresInDirection(m.GetMeasures(), direction, width)
+}
+
+// GetAverageDistancesFromDirections calculates the average distances for the specified directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// directions: The directions to calculate the average distances for.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction is not valid. The
+// directions without valid measures are omitted from the map.
+func (m *MockHandler) GetAverageDistancesFromDirections(
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromDirections(m.GetMeasures(), width, directions...)
+}
+
+// GetAverageDistancesFromAllDirections calculates the average distances for all cardinal directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not
+// valid. The directions without valid measures are omitted from the map.
+func (m *MockHandler) GetAverageDistancesFromAllDirections(
+	width int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromAllDirections(m.GetMeasures(), width)
+}
+
+// GetAverageDistancesFromPrimaryDirections calculates the average distances for the 8 primary cardinal directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with the primary cardinal directions as keys and their average distances as values, or an error if the width is
+// not valid. The directions without valid measures are omitted from the map.
+func (m *MockHandler) GetAverageDistancesFromPrimaryDirections(
+	width int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromPrimaryDirections(m.GetMeasures(), width)
+}
+
+// GetAverageDistancesWithWidths calculates the average distances for the specified directions, each one with its own
+// width.
+//
+// Parameters:
+//
+// widths: A map with the directions as keys and the sum of the angles to consider around them as values.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction or width is not
+// valid. The directions without valid measures are omitted from the map.
+func (m *MockHandler) GetAverageDistancesWithWidths(
+	widths map[CardinalDirection]int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesWithWidths(m.GetMeasures(), widths)
+}
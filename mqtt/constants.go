@@ -0,0 +1,14 @@
+package mqtt
+
+import (
+	"time"
+)
+
+var (
+	// DefaultPublishOptions is the default options used to publish the scans
+	DefaultPublishOptions = PublishOptions{
+		QoS:      0,
+		Retained: false,
+		Timeout:  5 * time.Second,
+	}
+)
@@ -0,0 +1,11 @@
+package mqtt
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilClient        = errors.New("mqtt client cannot be nil")
+	ErrEmptyTopic       = errors.New("mqtt topic cannot be empty")
+	ErrNoRotationEvents = errors.New("handler must implement the RotationNotifier interface to publish its scans")
+)
@@ -0,0 +1,18 @@
+module github.com/ralvarezdev/go-rplidar-sdk-handler/mqtt
+
+go 1.25.0
+
+require (
+	github.com/eclipse/paho.mqtt.golang v1.5.1
+	github.com/ralvarezdev/go-rplidar-sdk-handler v0.2.0
+)
+
+require (
+	github.com/gorilla/websocket v1.5.3 // indirect
+	github.com/ralvarezdev/go-concurrent-logger v0.1.8 // indirect
+	github.com/ralvarezdev/go-context v0.1.1 // indirect
+	github.com/ralvarezdev/go-crypto v0.6.4 // indirect
+	github.com/ralvarezdev/go-strings v0.1.12 // indirect
+	golang.org/x/net v0.44.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+)
@@ -0,0 +1,99 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+
+	gorplidarsdkhandler "github.com/ralvarezdev/go-rplidar-sdk-handler"
+)
+
+type (
+	// PublishOptions is the options used to publish the scans to the MQTT topic.
+	PublishOptions struct {
+		// QoS is the MQTT quality of service of each message
+		QoS byte
+
+		// Retained is whether the broker must retain the last scan
+		Retained bool
+
+		// Timeout is the maximum time to wait for each message to be published, or 0 to wait without timeout
+		Timeout time.Duration
+	}
+)
+
+// Publish snapshots the scan of the handler each time it completes a full rotation, and publishes it as JSON to the
+// given MQTT topic until the context is done. It consumes the rotation events of the handler, so they shouldn't be read
+// elsewhere while publishing.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation.
+// handler: The RPLiDAR handler to publish the scans of.
+// client: The connected MQTT client.
+// topic: The MQTT topic to publish the scans to.
+// opts: The options used to publish the scans.
+//
+// Returns:
+//
+// The context error once it's done, or an error if any parameter is not valid, if the handler doesn't implement the
+// RotationNotifier interface, or if a scan couldn't be published.
+func Publish(
+	ctx context.Context,
+	handler gorplidarsdkhandler.Handler,
+	client pahomqtt.Client,
+	topic string,
+	opts PublishOptions,
+) error {
+	// Check if the handler is nil
+	if handler == nil {
+		return gorplidarsdkhandler.ErrNilHandler
+	}
+
+	// Check if the client is nil
+	if client == nil {
+		return ErrNilClient
+	}
+
+	// Check if the topic is empty
+	if topic == "" {
+		return ErrEmptyTopic
+	}
+
+	// Check if the handler reports its completed rotations
+	rotationNotifier, ok := handler.(gorplidarsdkhandler.RotationNotifier)
+	if !ok {
+		return ErrNoRotationEvents
+	}
+
+	rotationEvents := rotationNotifier.RotationEvents()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-rotationEvents:
+		}
+
+		// Snapshot the scan
+		payload, err := json.Marshal(handler.GetMeasures())
+		if err != nil {
+			return fmt.Errorf("failed to encode scan: %w", err)
+		}
+
+		// Publish the scan and wait for it to be delivered
+		token := client.Publish(topic, opts.QoS, opts.Retained, payload)
+		if opts.Timeout > 0 {
+			if !token.WaitTimeout(opts.Timeout) {
+				return fmt.Errorf("timed out publishing scan to topic %s", topic)
+			}
+		} else {
+			token.Wait()
+		}
+		if err = token.Error(); err != nil {
+			return fmt.Errorf("failed to publish scan to topic %s: %w", topic, err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+
+	gorplidarsdkhandler "github.com/ralvarezdev/go-rplidar-sdk-handler"
+)
+
+type (
+	// fakeToken is a pahomqtt.Token that is already completed.
+	fakeToken struct {
+		err      error
+		timedOut bool
+	}
+
+	// fakeMessage is a message published through the fakeClient.
+	fakeMessage struct {
+		topic    string
+		qos      byte
+		retained bool
+		payload  []byte
+	}
+
+	// fakeClient is a pahomqtt.Client that records the published messages and completes their tokens with the given
+	// token. The rest of its methods are left unimplemented.
+	fakeClient struct {
+		pahomqtt.Client
+		token    *fakeToken
+		messages chan fakeMessage
+	}
+)
+
+// Wait returns immediately, since the token is already completed.
+func (t *fakeToken) Wait() bool {
+	return true
+}
+
+// WaitTimeout returns immediately, reporting the timeout if the token was created as timed out.
+func (t *fakeToken) WaitTimeout(time.Duration) bool {
+	return !t.timedOut
+}
+
+// Done returns a closed channel, since the token is already completed.
+func (t *fakeToken) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Error returns the error the token was created with.
+func (t *fakeToken) Error() error {
+	return t.err
+}
+
+// Publish records the message and returns the token of the client.
+func (c *fakeClient) Publish(topic string, qos byte, retained bool, payload interface{}) pahomqtt.Token {
+	c.messages <- fakeMessage{topic: topic, qos: qos, retained: retained, payload: payload.([]byte)}
+	return c.token
+}
+
+// newFakeClient creates a fakeClient that completes the published messages with the given token.
+func newFakeClient(token *fakeToken) *fakeClient {
+	return &fakeClient{token: token, messages: make(chan fakeMessage, 1)}
+}
+
+// newTestMockHandler creates a MockHandler with a single valid measure.
+func newTestMockHandler(t *testing.T) *gorplidarsdkhandler.MockHandler {
+	t.Helper()
+	mock, err := gorplidarsdkhandler.NewMockHandler(5000, 1)
+	if err != nil {
+		t.Fatalf("failed to create the mock: %v", err)
+	}
+	mock.SetMeasure(90, 1000, 47)
+	return mock
+}
+
+// TestPublish checks that each completed rotation publishes the JSON encoded scan, and that Publish returns the context
+// error once it's done.
+func TestPublish(t *testing.T) {
+	mock := newTestMockHandler(t)
+	client := newFakeClient(&fakeToken{})
+	opts := PublishOptions{QoS: 1, Retained: true, Timeout: time.Second}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Publish(ctx, mock, client, "rplidar/scan", opts)
+	}()
+
+	expectedPayload, err := json.Marshal(mock.GetMeasures())
+	if err != nil {
+		t.Fatalf("failed to encode the scan: %v", err)
+	}
+	for range 2 {
+		mock.CompleteRotation()
+		select {
+		case message := <-client.messages:
+			if message.topic != "rplidar/scan" || message.qos != opts.QoS || message.retained != opts.Retained {
+				t.Errorf("unexpected message: %+v", message)
+			}
+			if !bytes.Equal(message.payload, expectedPayload) {
+				t.Errorf("expected the payload %s, got %s", expectedPayload, message.payload)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the scan to be published")
+		}
+	}
+
+	cancelFn()
+	select {
+	case err = <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Publish to return")
+	}
+}
+
+// TestPublishErrors checks the errors returned for invalid parameters and for messages that couldn't be published.
+func TestPublishErrors(t *testing.T) {
+	errBroker := errors.New("broker error")
+
+	tests := []struct {
+		name        string
+		handler     func(t *testing.T) gorplidarsdkhandler.Handler
+		client      func() pahomqtt.Client
+		topic       string
+		expectedErr error
+	}{
+		{
+			name:        "nil handler",
+			handler:     func(*testing.T) gorplidarsdkhandler.Handler { return nil },
+			client:      func() pahomqtt.Client { return newFakeClient(&fakeToken{}) },
+			topic:       "rplidar/scan",
+			expectedErr: gorplidarsdkhandler.ErrNilHandler,
+		},
+		{
+			name:        "nil client",
+			handler:     func(t *testing.T) gorplidarsdkhandler.Handler { return newTestMockHandler(t) },
+			client:      func() pahomqtt.Client { return nil },
+			topic:       "rplidar/scan",
+			expectedErr: ErrNilClient,
+		},
+		{
+			name:        "empty topic",
+			handler:     func(t *testing.T) gorplidarsdkhandler.Handler { return newTestMockHandler(t) },
+			client:      func() pahomqtt.Client { return newFakeClient(&fakeToken{}) },
+			expectedErr: ErrEmptyTopic,
+		},
+		{
+			name: "handler without rotation events",
+			handler: func(t *testing.T) gorplidarsdkhandler.Handler {
+				// Hide the optional interfaces of the mock behind the bare Handler interface
+				return struct{ gorplidarsdkhandler.Handler }{newTestMockHandler(t)}
+			},
+			client:      func() pahomqtt.Client { return newFakeClient(&fakeToken{}) },
+			topic:       "rplidar/scan",
+			expectedErr: ErrNoRotationEvents,
+		},
+		{
+			name:        "broker error",
+			handler:     func(t *testing.T) gorplidarsdkhandler.Handler { return newTestMockHandler(t) },
+			client:      func() pahomqtt.Client { return newFakeClient(&fakeToken{err: errBroker}) },
+			topic:       "rplidar/scan",
+			expectedErr: errBroker,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := test.handler(t)
+
+			// Complete a rotation so the valid parameters reach the broker
+			if mock, ok := handler.(*gorplidarsdkhandler.MockHandler); ok {
+				mock.CompleteRotation()
+			}
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+			err := Publish(ctx, handler, test.client(), test.topic, DefaultPublishOptions)
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("expected %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+// TestPublishTimeout checks that Publish fails if a message isn't delivered before the timeout.
+func TestPublishTimeout(t *testing.T) {
+	mock := newTestMockHandler(t)
+	mock.CompleteRotation()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+	err := Publish(ctx, mock, newFakeClient(&fakeToken{timedOut: true}), "rplidar/scan", DefaultPublishOptions)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a publish timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,432 @@
+package go_rplidar_sdk_handler
+
+import (
+	"io"
+	"time"
+)
+
+type (
+	// Option is a function that configures an optional setting of a DefaultHandler
+	Option func(h *DefaultHandler)
+)
+
+// WithSubscriberBufferSize sets the buffer size of each channel returned by Subscribe.
+//
+// Parameters:
+//
+// size: Buffer size of each subscriber channel.
+//
+// Returns:
+//
+// An Option that sets the subscriber buffer size.
+func WithSubscriberBufferSize(size int) Option {
+	return func(h *DefaultHandler) {
+		h.subscriberBufferSize = size
+	}
+}
+
+// WithSubscriberOverflowPolicy sets what to do when a subscriber channel is full.
+//
+// Parameters:
+//
+// policy: Overflow policy for the subscriber channels.
+//
+// Returns:
+//
+// An Option that sets the subscriber overflow policy.
+func WithSubscriberOverflowPolicy(policy SubscriberOverflowPolicy) Option {
+	return func(h *DefaultHandler) {
+		h.subscriberOverflowPolicy = policy
+	}
+}
+
+// WithIgnoreFirstStdoutMessages sets the number of initial stdout messages to ignore, which depends on the banner
+// printed by the ultra_simple build.
+//
+// Parameters:
+//
+// count: Number of initial stdout messages to ignore.
+//
+// Returns:
+//
+// An Option that sets the number of initial stdout messages to ignore.
+func WithIgnoreFirstStdoutMessages(count int) Option {
+	return func(h *DefaultHandler) {
+		h.ignoreFirstStdoutMessages = count
+	}
+}
+
+// WithExtraArgs sets additional arguments appended to the ultra_simple arguments, such as the scan mode.
+//
+// Parameters:
+//
+// args: Extra arguments for the ultra_simple executable.
+//
+// Returns:
+//
+// An Option that sets the extra ultra_simple arguments.
+func WithExtraArgs(args ...string) Option {
+	return func(h *DefaultHandler) {
+		h.extraArgs = append([]string(nil), args...)
+	}
+}
+
+// WithCloseTimeout sets the time to wait for ultra_simple to exit gracefully before killing it.
+//
+// Parameters:
+//
+// timeout: Timeout for closing the handler.
+//
+// Returns:
+//
+// An Option that sets the close timeout.
+func WithCloseTimeout(timeout time.Duration) Option {
+	return func(h *DefaultHandler) {
+		h.closeTimeout = timeout
+	}
+}
+
+// WithBucketsPerDegree sets the number of buckets per degree to keep the measures at an angular resolution finer than
+// 1 degree, which is used by the analysis helpers. GetMeasures keeps returning the latest measure per degree.
+//
+// Parameters:
+//
+// bucketsPerDegree: Number of buckets per degree, 1 keeps the 360 buckets resolution.
+//
+// Returns:
+//
+// An Option that sets the number of buckets per degree.
+func WithBucketsPerDegree(bucketsPerDegree int) Option {
+	return func(h *DefaultHandler) {
+		h.bucketsPerDegree = bucketsPerDegree
+	}
+}
+
+// WithAccumulateMeasures enables keeping every measure received for each degree during the current rotation, instead
+// of only the latest one. The accumulated measures are cleared at each sync bit.
+//
+// Parameters:
+//
+// accumulate: If true, the measures of each degree are accumulated.
+//
+// Returns:
+//
+// An Option that sets the accumulation mode.
+func WithAccumulateMeasures(accumulate bool) Option {
+	return func(h *DefaultHandler) {
+		h.accumulateMeasures = accumulate
+	}
+}
+
+// WithMeasureTTL sets the time after which a stored measure is considered stale and treated as nil by GetMeasures and
+// the analysis helpers.
+//
+// Parameters:
+//
+// ttl: Time to live of each measure, or 0 to keep the measures until they are overwritten.
+//
+// Returns:
+//
+// An Option that sets the measure TTL.
+func WithMeasureTTL(ttl time.Duration) Option {
+	return func(h *DefaultHandler) {
+		h.measureTTL = ttl
+	}
+}
+
+// WithMinDistanceLimit sets the dead zone around the RPLiDAR, so the measures closer than it, usually reflections of the
+// chassis or the sensor glass, are dropped.
+//
+// Parameters:
+//
+// minDistanceLimit: Minimum distance limit for valid measurements, or 0 to keep every measure.
+//
+// Returns:
+//
+// An Option that sets the min distance limit.
+func WithMinDistanceLimit(minDistanceLimit float64) Option {
+	return func(h *DefaultHandler) {
+		h.minDistanceLimit = minDistanceLimit
+	}
+}
+
+// WithDataTimeout sets the time without any parsed measure after which the run is stopped with ErrDataTimeout, so a hung
+// ultra_simple process doesn't block Run forever.
+//
+// Parameters:
+//
+// dataTimeout: Time to wait for each measure, or 0 to wait forever.
+//
+// Returns:
+//
+// An Option that sets the data timeout.
+func WithDataTimeout(dataTimeout time.Duration) Option {
+	return func(h *DefaultHandler) {
+		h.dataTimeout = dataTimeout
+	}
+}
+
+// WithMountPose sets the pose of the RPLiDAR relative to the robot frame, used by GetPointCloudRobotFrame.
+//
+// Parameters:
+//
+// pose: The mount pose of the RPLiDAR.
+//
+// Returns:
+//
+// An Option that sets the mount pose.
+func WithMountPose(pose MountPose) Option {
+	return func(h *DefaultHandler) {
+		h.mountPose = pose
+	}
+}
+
+// WithBufferSizes sets the initial and the maximum size of the buffer of each line scanner, so the memory used can be
+// reduced on constrained boards.
+//
+// Parameters:
+//
+// initialSize: Initial size in bytes of the buffer for reading lines.
+// maxSize: Maximum size in bytes of the buffer for reading lines.
+//
+// Returns:
+//
+// An Option that sets the buffer sizes.
+func WithBufferSizes(initialSize int, maxSize int) Option {
+	return func(h *DefaultHandler) {
+		h.initialBufferSize = initialSize
+		h.maxBufferSize = maxSize
+	}
+}
+
+// WithMaxLineLength sets the maximum length of the lines read from ultra_simple, so the garbage lines caused by a
+// misconfigured serial link are logged and skipped instead of being buffered.
+//
+// Parameters:
+//
+// maxLineLength: Maximum length in bytes of a line, or 0 to only limit it by the max buffer size.
+//
+// Returns:
+//
+// An Option that sets the max line length.
+func WithMaxLineLength(maxLineLength int) Option {
+	return func(h *DefaultHandler) {
+		h.maxLineLength = maxLineLength
+	}
+}
+
+// WithSkipPortCheck skips the check that the port device exists when the handler is created, e.g. to create the handler
+// on a machine without the RPLiDAR connected.
+//
+// Returns:
+//
+// An Option that skips the port check.
+func WithSkipPortCheck() Option {
+	return func(h *DefaultHandler) {
+		h.skipPortCheck = true
+	}
+}
+
+// WithParserConfig sets the layout of the measure lines printed by ultra_simple, for builds that reorder the fields or
+// use a different separator.
+//
+// Parameters:
+//
+// parserConfig: Layout of the measure lines.
+//
+// Returns:
+//
+// An Option that sets the parser config.
+func WithParserConfig(parserConfig ParserConfig) Option {
+	return func(h *DefaultHandler) {
+		h.parserConfig = parserConfig
+	}
+}
+
+// WithFailOnUnhealthyDevice makes the run fail with ErrUnhealthyDevice when ultra_simple reports a health status other
+// than good, instead of only logging it.
+//
+// Returns:
+//
+// An Option that fails the run on an unhealthy device.
+func WithFailOnUnhealthyDevice() Option {
+	return func(h *DefaultHandler) {
+		h.failOnUnhealthyDevice = true
+	}
+}
+
+// WithScanHistorySize sets the number of complete scans kept in the scan history returned by GetScanHistory.
+//
+// Parameters:
+//
+// size: Number of complete scans to keep, or 0 to disable the scan history.
+//
+// Returns:
+//
+// An Option that sets the scan history size.
+func WithScanHistorySize(size int) Option {
+	return func(h *DefaultHandler) {
+		h.scanHistorySize = size
+	}
+}
+
+// WithCaptureWriter tees the raw stdout lines of ultra_simple to the given writer while they're parsed, e.g. to record a
+// session that can be replayed later with NewReplayHandler. The lines are written from a separate goroutine, and
+// dropped if the writer falls behind, so a slow writer doesn't block the parsing.
+//
+// Parameters:
+//
+// w: Writer to write the raw stdout lines to.
+//
+// Returns:
+//
+// An Option that sets the capture writer.
+func WithCaptureWriter(w io.Writer) Option {
+	return func(h *DefaultHandler) {
+		h.captureWriter = w
+	}
+}
+
+// WithQualityScale sets the maximum quality reported by the RPLiDAR model, used to normalize the quality of the
+// measures. The model-specific constructors set it for their model.
+//
+// Parameters:
+//
+// qualityScale: Maximum quality reported by the RPLiDAR model.
+//
+// Returns:
+//
+// An Option that sets the quality scale.
+func WithQualityScale(qualityScale int) Option {
+	return func(h *DefaultHandler) {
+		h.qualityScale = qualityScale
+	}
+}
+
+// WithParserWorkers parses the stdout lines of ultra_simple in a pool of goroutines, so the loop that reads the pipe only
+// queues the lines and doesn't back-pressure ultra_simple at high scan rates. The parsed lines are still handled in the
+// order they were read.
+//
+// Parameters:
+//
+// workers: Number of parser goroutines, or 0 to parse the lines in the loop that reads the pipe.
+//
+// Returns:
+//
+// An Option that sets the number of parser workers.
+func WithParserWorkers(workers int) Option {
+	return func(h *DefaultHandler) {
+		h.parserWorkers = workers
+	}
+}
+
+// WithMeasurePool reuses the parsed Measure instances once they're overwritten or dropped, reducing the allocations at
+// high line rates. The measures handed out to the callers are copies, so they're never reused. It can't be combined
+// with WithAccumulateMeasures or WithBucketsPerDegree above 1, which keep references to the stored measures.
+//
+// The scan history copies every stored measure on each rotation, so the pool only removes the allocations of the
+// measures if the scan history is also disabled with WithScanHistorySize(0).
+//
+// Returns:
+//
+// An Option that enables the measure pool.
+func WithMeasurePool() Option {
+	return func(h *DefaultHandler) {
+		h.measurePool = newMeasurePool()
+	}
+}
+
+// WithClock sets the clock used to timestamp the measures, the scans and the snapshots, and to check the measure TTL,
+// so the timestamps and the scan frequency, which is computed from the timestamps of the rotations, can be driven
+// deterministically. The data timeout and the replay pacing keep using the system clock, since they rely on timers.
+//
+// Parameters:
+//
+// clock: Function that returns the current time, or nil to use time.Now.
+//
+// Returns:
+//
+// An Option that sets the clock.
+func WithClock(clock func() time.Time) Option {
+	return func(h *DefaultHandler) {
+		h.clock = clock
+	}
+}
+
+// WithHealthThresholds sets the thresholds checked by Healthy.
+//
+// Parameters:
+//
+// maxDataAge: Time without any parsed measure after which the handler is unhealthy.
+// minScanFrequency: Scan frequency in Hz below which the handler is unhealthy, or 0 to skip the check.
+// minCoverage: Fraction of angles with a valid measure below which the handler is unhealthy, or 0 to skip the check.
+//
+// Returns:
+//
+// An Option that sets the health thresholds.
+func WithHealthThresholds(maxDataAge time.Duration, minScanFrequency float64, minCoverage float64) Option {
+	return func(h *DefaultHandler) {
+		h.healthMaxDataAge = maxDataAge
+		h.healthMinScanFrequency = minScanFrequency
+		h.healthMinCoverage = minCoverage
+	}
+}
+
+// WithMinimumQuality sets the minimum quality for a measure to be stored, for the handlers that don't take it as a
+// parameter, such as the replay handler.
+//
+// Parameters:
+//
+// minimumQuality: Minimum quality for a valid measurement.
+//
+// Returns:
+//
+// An Option that sets the minimum quality.
+func WithMinimumQuality(minimumQuality int) Option {
+	return func(h *DefaultHandler) {
+		h.minimumQuality = minimumQuality
+	}
+}
+
+// WithMeasuresChannelSize sets the size of the channel returned by GetMeasuresChannel, for the handlers that don't take
+// it as a parameter, such as the replay handler.
+//
+// Parameters:
+//
+// size: Size of the channel to send measures.
+//
+// Returns:
+//
+// An Option that sets the measures channel size.
+func WithMeasuresChannelSize(size int) Option {
+	return func(h *DefaultHandler) {
+		h.measuresChSize = size
+	}
+}
+
+// WithDebug enables the debug logging, for the handlers that don't take it as a parameter, such as the replay handler.
+//
+// Returns:
+//
+// An Option that enables the debug logging.
+func WithDebug() Option {
+	return func(h *DefaultHandler) {
+		h.debug = true
+	}
+}
+
+// WithReplayRotationPeriod paces the rotations replayed by NewReplayHandler, to reproduce the cadence of the captured
+// session. By default, the lines are replayed as fast as possible.
+//
+// Parameters:
+//
+// rotationPeriod: Time to pace each replayed rotation, or 0 to replay the lines as fast as possible.
+//
+// Returns:
+//
+// An Option that sets the replay rotation period.
+func WithReplayRotationPeriod(rotationPeriod time.Duration) Option {
+	return func(h *DefaultHandler) {
+		h.replayRotationPeriod = rotationPeriod
+	}
+}
@@ -0,0 +1,97 @@
+package go_rplidar_sdk_handler
+
+import (
+	"sync"
+)
+
+type (
+	// parserJob is a stdout line queued to the parser workers
+	parserJob struct {
+		line    string
+		measure *Measure
+		err     error
+		doneCh  chan struct{}
+	}
+)
+
+// newStdoutLineHandler creates the function to handle the stdout lines, which queues them to the parser workers if
+// they're enabled.
+//
+// Returns:
+//
+// The function to handle each stdout line, and the function to call once the lines have been read, which waits until
+// the queued lines are handled and returns the first error returned while handling them.
+func (h *DefaultHandler) newStdoutLineHandler() (func(line string) error, func() error) {
+	// Check if the parser workers are disabled
+	if h.parserWorkers == 0 {
+		return h.handleStdoutLine, func() error { return nil }
+	}
+
+	jobsCh := make(chan *parserJob, ParserPoolQueueSize)
+	orderCh := make(chan *parserJob, ParserPoolQueueSize)
+
+	// Parse the lines concurrently
+	var workersWg sync.WaitGroup
+	for range h.parserWorkers {
+		workersWg.Go(
+			func() {
+				for job := range jobsCh {
+					job.measure, job.err = h.parseStdoutLine(job.line)
+					close(job.doneCh)
+				}
+			},
+		)
+	}
+
+	// Handle the parsed lines in the order they were read
+	var handleErr error
+	var handleErrMutex sync.Mutex
+	handlerDoneCh := make(chan struct{})
+	go func() {
+		defer close(handlerDoneCh)
+
+		failed := false
+		for job := range orderCh {
+			<-job.doneCh
+
+			// Check if a previous line failed, keep draining the queue so the reader doesn't block
+			if failed {
+				continue
+			}
+			if err := h.handleParsedStdoutLine(job.line, job.measure, job.err); err != nil {
+				handleErrMutex.Lock()
+				handleErr = err
+				handleErrMutex.Unlock()
+				failed = true
+			}
+		}
+	}()
+
+	lineHandler := func(line string) error {
+		// Check if a previous line failed
+		handleErrMutex.Lock()
+		err := handleErr
+		handleErrMutex.Unlock()
+		if err != nil {
+			return err
+		}
+
+		// Queue the line in order before it's parsed
+		job := &parserJob{line: line, doneCh: make(chan struct{})}
+		orderCh <- job
+		jobsCh <- job
+		return nil
+	}
+
+	stopFn := func() error {
+		close(jobsCh)
+		close(orderCh)
+		workersWg.Wait()
+		<-handlerDoneCh
+
+		handleErrMutex.Lock()
+		defer handleErrMutex.Unlock()
+		return handleErr
+	}
+	return lineHandler, stopFn
+}
@@ -0,0 +1,114 @@
+package go_rplidar_sdk_handler
+
+import (
+	"fmt"
+	"testing"
+)
+
+// handleTestLines handles the lines through the stdout line handler of a new handler with the given parser workers.
+func handleTestLines(tb testing.TB, workers int, lines []string) *DefaultHandler {
+	tb.Helper()
+	h := newTestLineHandler(tb, WithParserWorkers(workers), WithScanHistorySize(8))
+	lineHandler, stopFn := h.newStdoutLineHandler()
+	for _, line := range lines {
+		if err := lineHandler(line); err != nil {
+			tb.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+	if err := stopFn(); err != nil {
+		tb.Fatalf("failed to stop the line handler: %v", err)
+	}
+	return h
+}
+
+// measureValues returns the values of a measure that don't depend on the time it was parsed at.
+func measureValues(measure *Measure) string {
+	if measure == nil {
+		return "nil"
+	}
+	return fmt.Sprintf(
+		"%f %f %d %t",
+		measure.GetAngle(),
+		measure.GetDistance(),
+		measure.GetQuality(),
+		measure.IsRotationCompleted(),
+	)
+}
+
+// TestParserWorkersKeepOrder checks that the lines parsed by the parser workers are handled in the order they were read,
+// so the rotations, the stored measures and the scan history match the inline parsing.
+func TestParserWorkersKeepOrder(t *testing.T) {
+	lines := testScanLines(6, 1)
+	expected := handleTestLines(t, 0, lines)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			h := handleTestLines(t, workers, lines)
+
+			// Check the rotations and the stats
+			if h.GetRotationCount() != expected.GetRotationCount() {
+				t.Errorf("expected %d rotations, got %d", expected.GetRotationCount(), h.GetRotationCount())
+			}
+			if h.GetStats() != expected.GetStats() {
+				t.Errorf("expected stats %+v, got %+v", expected.GetStats(), h.GetStats())
+			}
+
+			// Check the stored measures
+			expectedMeasures := expected.GetMeasures()
+			for angle, measure := range h.GetMeasures() {
+				if measureValues(measure) != measureValues(expectedMeasures[angle]) {
+					t.Fatalf(
+						"expected measure %s at angle %d, got %s",
+						measureValues(expectedMeasures[angle]),
+						angle,
+						measureValues(measure),
+					)
+				}
+			}
+
+			// Check the scans captured on each rotation
+			expectedHistory := expected.GetScanHistory()
+			history := h.GetScanHistory()
+			if len(history) != len(expectedHistory) {
+				t.Fatalf("expected %d scans in the history, got %d", len(expectedHistory), len(history))
+			}
+			for index, scan := range history {
+				expectedScanMeasures := expectedHistory[index].GetMeasures()
+				for angle, measure := range scan.GetMeasures() {
+					if measureValues(measure) != measureValues(expectedScanMeasures[angle]) {
+						t.Fatalf(
+							"expected measure %s at angle %d of the scan %d, got %s",
+							measureValues(expectedScanMeasures[angle]),
+							angle,
+							index,
+							measureValues(measure),
+						)
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParserWorkers benchmarks the throughput of the stdout line handler inline and with the parser workers.
+func BenchmarkParserWorkers(b *testing.B) {
+	lines := testScanLines(1, 1)
+	for _, workers := range []int{0, 2, 4} {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			h := newTestLineHandler(b, WithParserWorkers(workers))
+			lineHandler, stopFn := h.newStdoutLineHandler()
+
+			b.ReportAllocs()
+			index := 0
+			for b.Loop() {
+				if err := lineHandler(lines[index]); err != nil {
+					b.Fatal(err)
+				}
+				index = (index + 1) % len(lines)
+			}
+			if err := stopFn(); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package go_rplidar_sdk_handler
+
+import (
+	"sync"
+)
+
+// newMeasurePool creates the pool of the Measure instances reused by the handler.
+//
+// Returns:
+//
+// A pointer to a sync.Pool of Measure instances.
+func newMeasurePool() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			return &Measure{}
+		},
+	}
+}
+
+// releaseMeasure returns a measure that's no longer referenced by the handler to the pool.
+//
+// Parameters:
+//
+// measure: The measure to release, or nil.
+func (h *DefaultHandler) releaseMeasure(measure *Measure) {
+	// Check if the measures are pooled
+	if h.measurePool == nil || measure == nil {
+		return
+	}
+	h.measurePool.Put(measure)
+}
+
+// exportMeasure returns the measure to hand out to the callers. If the measures are pooled it's a copy, since the
+// stored measure is reused once it's overwritten.
+//
+// Parameters:
+//
+// measure: The stored measure.
+//
+// Returns:
+//
+// The measure itself, or a copy of it if the measures are pooled.
+func (h *DefaultHandler) exportMeasure(measure *Measure) *Measure {
+	// Check if the measures are pooled
+	if h.measurePool == nil || measure == nil {
+		return measure
+	}
+	return measure.clone()
+}
+
+// copyMeasures copies the stored measures to the destination, copying each measure if the measures are pooled. The
+// measures lock must be held.
+//
+// Parameters:
+//
+// dst: The destination of the copy.
+// src: The stored measures.
+func (h *DefaultHandler) copyMeasures(dst []*Measure, src []*Measure) {
+	// Check if the measures are pooled
+	if h.measurePool == nil {
+		copy(dst, src)
+		return
+	}
+	for index := range min(len(dst), len(src)) {
+		dst[index] = h.exportMeasure(src[index])
+	}
+}
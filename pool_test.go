@@ -0,0 +1,111 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// benchmarkHandleStdoutLine benchmarks handling the lines of full rotations with the given options.
+func benchmarkHandleStdoutLine(b *testing.B, options ...Option) {
+	h := newTestLineHandler(b, options...)
+	lines := testScanLines(1, 1)
+
+	b.ReportAllocs()
+	index := 0
+	for b.Loop() {
+		if err := h.handleStdoutLine(lines[index]); err != nil {
+			b.Fatal(err)
+		}
+		index = (index + 1) % len(lines)
+	}
+}
+
+// BenchmarkHandleStdoutLine benchmarks handling a plain measure line, allocating each measure.
+func BenchmarkHandleStdoutLine(b *testing.B) {
+	benchmarkHandleStdoutLine(b, WithScanHistorySize(0))
+}
+
+// BenchmarkHandleStdoutLinePooled benchmarks handling a plain measure line with the measure pool.
+func BenchmarkHandleStdoutLinePooled(b *testing.B) {
+	benchmarkHandleStdoutLine(b, WithScanHistorySize(0), WithMeasurePool())
+}
+
+// BenchmarkHandleStdoutLineWithScanHistory benchmarks handling a plain measure line with the default scan history,
+// which snapshots the measures on each rotation.
+func BenchmarkHandleStdoutLineWithScanHistory(b *testing.B) {
+	benchmarkHandleStdoutLine(b)
+}
+
+// BenchmarkHandleStdoutLinePooledWithScanHistory benchmarks handling a plain measure line with the measure pool and the
+// default scan history, whose snapshots must copy the pooled measures.
+func BenchmarkHandleStdoutLinePooledWithScanHistory(b *testing.B) {
+	benchmarkHandleStdoutLine(b, WithMeasurePool())
+}
+
+// TestMeasurePoolDoesNotAliasExportedMeasures checks that the measures returned by GetMeasures, sent through the
+// measures channel or sent to a subscriber are unchanged after their slot is overwritten and the pooled measure reused.
+func TestMeasurePoolDoesNotAliasExportedMeasures(t *testing.T) {
+	r, w := io.Pipe()
+	t.Cleanup(func() {
+		_ = w.Close()
+	})
+	h := newTestReaderHandler(t, r, WithMeasurePool())
+	runTestHandler(t, h)
+
+	// Start receiving the measures through the channel and a subscriber
+	if err := h.StartSendingMeasures(); err != nil {
+		t.Fatalf("failed to start sending the measures: %v", err)
+	}
+	measuresCh, err := h.GetMeasuresChannel()
+	if err != nil {
+		t.Fatalf("failed to get the measures channel: %v", err)
+	}
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	subscriberCh, err := h.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	// Store the first measure
+	writeLine := func(line string) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			t.Fatalf("failed to write the line: %v", err)
+		}
+	}
+	writeLine("10.50 1000.00 47")
+	waitFor(t, "the first measure", func() bool {
+		measure := h.GetMeasures()[10]
+		return measure != nil && measure.GetDistance() == 1000
+	})
+	exportedMeasures := map[string]*Measure{
+		"GetMeasures": h.GetMeasures()[10],
+		"channel":     <-measuresCh,
+		"subscriber":  <-subscriberCh,
+	}
+
+	// Overwrite the slot, returning the first measure to the pool, and parse more lines to reuse it
+	writeLine("10.50 2000.00 40")
+	for angle := 20; angle < 60; angle++ {
+		writeLine(fmt.Sprintf("%d.50 3000.00 30", angle))
+	}
+	waitFor(t, "the overwriting measures", func() bool {
+		measures := h.GetMeasures()
+		return measures[10] != nil && measures[10].GetDistance() == 2000 && measures[59] != nil
+	})
+
+	// Check the exported measures kept their values
+	for source, measure := range exportedMeasures {
+		if measure.GetAngle() != 10.5 || measure.GetDistance() != 1000 || measure.GetQuality() != 47 {
+			t.Errorf(
+				"measure from %s changed to angle %f, distance %f and quality %d",
+				source,
+				measure.GetAngle(),
+				measure.GetDistance(),
+				measure.GetQuality(),
+			)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// checkPortExists checks if the device of the port exists.
+//
+// Parameters:
+//
+// port: The serial port of the RPLiDAR device.
+//
+// Returns:
+//
+// An error if the device doesn't exist.
+func checkPortExists(port string) error {
+	if _, err := os.Stat(port); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrPortNotFound, port)
+	}
+	return nil
+}
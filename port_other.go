@@ -0,0 +1,16 @@
+//go:build !linux
+
+package go_rplidar_sdk_handler
+
+// checkPortExists is a no-op on the platforms where the ports aren't device files.
+//
+// Parameters:
+//
+// port: The serial port of the RPLiDAR device.
+//
+// Returns:
+//
+// Always nil.
+func checkPortExists(port string) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+package go_rplidar_sdk_handler
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+
+	goconcurrentlogger "github.com/ralvarezdev/go-concurrent-logger"
+)
+
+// stopProcess requests the process to stop gracefully, waits for the close timeout and then kills it.
+//
+// Parameters:
+//
+// cmd: The command of the started process.
+// loggerProducer: Logger producer used to log how the process stopped.
+//
+// Returns:
+//
+// The error returned by waiting for the process.
+func (h *DefaultHandler) stopProcess(cmd *exec.Cmd, loggerProducer goconcurrentlogger.LoggerProducer) error {
+	return h.stopProcessWith(
+		cmd.Wait,
+		func() error {
+			return requestGracefulStop(cmd.Process)
+		},
+		cmd.Process.Kill,
+		loggerProducer,
+	)
+}
+
+// stopProcessWith runs the stop sequence of a process: it requests the process to stop gracefully, waits for the close
+// timeout and then kills it.
+//
+// Parameters:
+//
+// wait: Function that waits for the process to exit.
+// requestStop: Function that requests the process to stop gracefully.
+// kill: Function that kills the process.
+// loggerProducer: Logger producer used to log how the process stopped.
+//
+// Returns:
+//
+// The error returned by waiting for the process.
+func (h *DefaultHandler) stopProcessWith(
+	wait, requestStop, kill func() error,
+	loggerProducer goconcurrentlogger.LoggerProducer,
+) error {
+	// Wait for the process to exit in the background
+	waitErrCh := make(chan error, 1)
+	go func() {
+		waitErrCh <- wait()
+	}()
+
+	// Request the process to stop gracefully with the platform specific mechanism
+	if err := requestStop(); err != nil && loggerProducer.IsDebug() {
+		loggerProducer.Debug(
+			fmt.Sprintf(
+				"Failed to request the RPLiDAR process to stop: %v",
+				err,
+			),
+		)
+	}
+
+	// Wait for the process to exit or timeout
+	select {
+	case err := <-waitErrCh:
+		// Process exited gracefully
+		loggerProducer.Info("RPLiDAR process exited gracefully")
+		return err
+	case <-time.After(h.closeTimeout):
+		// Timeout, force kill
+		_ = kill()
+		loggerProducer.Warning("RPLiDAR process killed after timeout")
+		return <-waitErrCh
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+type (
+	// processSignaler is the subset of os.Process used to request a graceful stop, so it can be replaced in the tests.
+	processSignaler interface {
+		Signal(sig os.Signal) error
+	}
+)
+
+// requestGracefulStop requests the process to stop with SIGINT, falling back to SIGTERM.
+//
+// Parameters:
+//
+// process: The process to stop.
+//
+// Returns:
+//
+// An error if the process couldn't be signaled.
+func requestGracefulStop(process processSignaler) error {
+	err := process.Signal(syscall.SIGINT)
+	if err == nil || errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return process.Signal(syscall.SIGTERM)
+}
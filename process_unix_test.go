@@ -0,0 +1,168 @@
+//go:build !windows
+
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeProcess is a process that records the stop calls, and exits when it's killed or, optionally, signaled.
+type fakeProcess struct {
+	mutex        sync.Mutex
+	calls        []string
+	signalErrors map[os.Signal]error
+	exitOnSignal bool
+	exitedCh     chan struct{}
+	exitOnce     sync.Once
+}
+
+// newFakeProcess creates a new fakeProcess instance.
+func newFakeProcess(exitOnSignal bool, signalErrors map[os.Signal]error) *fakeProcess {
+	return &fakeProcess{
+		signalErrors: signalErrors,
+		exitOnSignal: exitOnSignal,
+		exitedCh:     make(chan struct{}),
+	}
+}
+
+func (p *fakeProcess) record(call string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.calls = append(p.calls, call)
+}
+
+func (p *fakeProcess) getCalls() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return slices.Clone(p.calls)
+}
+
+func (p *fakeProcess) exit() {
+	p.exitOnce.Do(func() {
+		close(p.exitedCh)
+	})
+}
+
+func (p *fakeProcess) Signal(sig os.Signal) error {
+	p.record("signal " + sig.String())
+	if err := p.signalErrors[sig]; err != nil {
+		return err
+	}
+	if p.exitOnSignal {
+		p.exit()
+	}
+	return nil
+}
+
+func (p *fakeProcess) Kill() error {
+	p.record("kill")
+	p.exit()
+	return nil
+}
+
+func (p *fakeProcess) Wait() error {
+	<-p.exitedCh
+	return nil
+}
+
+// stopFakeProcess runs the Unix stop sequence over a fake process.
+func stopFakeProcess(process *fakeProcess, closeTimeout time.Duration) (time.Duration, error) {
+	h := &DefaultHandler{
+		closeTimeout: closeTimeout,
+	}
+
+	start := time.Now()
+	err := h.stopProcessWith(
+		process.Wait,
+		func() error {
+			return requestGracefulStop(process)
+		},
+		process.Kill,
+		nopLoggerProducer{},
+	)
+	return time.Since(start), err
+}
+
+// TestStopProcessSignalsBeforeKill checks the order of the stop calls, and the fallback to Kill after the close
+// timeout.
+func TestStopProcessSignalsBeforeKill(t *testing.T) {
+	closeTimeout := 50 * time.Millisecond
+	tests := []struct {
+		name          string
+		exitOnSignal  bool
+		signalErrors  map[os.Signal]error
+		expectedCalls []string
+		expectKill    bool
+	}{
+		{
+			name:          "exits on SIGINT",
+			exitOnSignal:  true,
+			expectedCalls: []string{"signal " + syscall.SIGINT.String()},
+		},
+		{
+			name: "ignores the signals",
+			expectedCalls: []string{
+				"signal " + syscall.SIGINT.String(),
+				"kill",
+			},
+			expectKill: true,
+		},
+		{
+			name:         "falls back to SIGTERM",
+			exitOnSignal: true,
+			signalErrors: map[os.Signal]error{syscall.SIGINT: errors.New("signal not supported")},
+			expectedCalls: []string{
+				"signal " + syscall.SIGINT.String(),
+				"signal " + syscall.SIGTERM.String(),
+			},
+		},
+		{
+			name:         "falls back to kill after SIGTERM",
+			signalErrors: map[os.Signal]error{syscall.SIGINT: errors.New("signal not supported")},
+			expectedCalls: []string{
+				"signal " + syscall.SIGINT.String(),
+				"signal " + syscall.SIGTERM.String(),
+				"kill",
+			},
+			expectKill: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			process := newFakeProcess(test.exitOnSignal, test.signalErrors)
+			elapsed, err := stopFakeProcess(process, closeTimeout)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls := process.getCalls(); !slices.Equal(calls, test.expectedCalls) {
+				t.Errorf("expected calls %v, got %v", test.expectedCalls, calls)
+			}
+
+			// Check the kill waited for the close timeout, and the graceful stop didn't
+			if test.expectKill && elapsed < closeTimeout {
+				t.Errorf("expected the kill after %s, got it after %s", closeTimeout, elapsed)
+			}
+			if !test.expectKill && elapsed >= closeTimeout {
+				t.Errorf("expected the process to exit before %s, got %s", closeTimeout, elapsed)
+			}
+		})
+	}
+}
+
+// TestRequestGracefulStopProcessDone checks that an exited process isn't signaled again with SIGTERM.
+func TestRequestGracefulStopProcessDone(t *testing.T) {
+	process := newFakeProcess(false, map[os.Signal]error{syscall.SIGINT: os.ErrProcessDone})
+	if err := requestGracefulStop(process); !errors.Is(err, os.ErrProcessDone) {
+		t.Errorf("expected os.ErrProcessDone, got %v", err)
+	}
+	if calls := process.getCalls(); !slices.Equal(calls, []string{"signal " + syscall.SIGINT.String()}) {
+		t.Errorf("expected only SIGINT, got %v", calls)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build windows
+
+package go_rplidar_sdk_handler
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// requestGracefulStop requests the process to stop with taskkill, since Windows doesn't support sending os.Interrupt
+// to other processes.
+//
+// Parameters:
+//
+// process: The process to stop.
+//
+// Returns:
+//
+// An error if taskkill failed.
+func requestGracefulStop(process *os.Process) error {
+	return exec.Command(
+		"taskkill",
+		"/PID",
+		strconv.Itoa(process.Pid),
+		"/T",
+	).Run()
+}
@@ -0,0 +1,136 @@
+package prometheus
+
+import (
+	goprometheus "github.com/prometheus/client_golang/prometheus"
+
+	gorplidarsdkhandler "github.com/ralvarezdev/go-rplidar-sdk-handler"
+)
+
+type (
+	// Collector is a Prometheus collector that reports the metrics of a RPLiDAR handler.
+	Collector struct {
+		handler             gorplidarsdkhandler.Handler
+		scanFrequencyDesc   *goprometheus.Desc
+		coverageRatioDesc   *goprometheus.Desc
+		parseErrorsDesc     *goprometheus.Desc
+		nearestObstacleDesc *goprometheus.Desc
+		runningDesc         *goprometheus.Desc
+	}
+)
+
+// NewCollector creates a new Collector instance.
+//
+// Parameters:
+//
+// handler: The RPLiDAR handler to collect the metrics from.
+// constLabels: Labels added to every metric, used to tell apart several RPLiDAR devices.
+//
+// Returns:
+//
+// A pointer to a Collector instance or an error if the handler is nil.
+func NewCollector(
+	handler gorplidarsdkhandler.Handler,
+	constLabels goprometheus.Labels,
+) (*Collector, error) {
+	// Check if the handler is nil
+	if handler == nil {
+		return nil, gorplidarsdkhandler.ErrNilHandler
+	}
+
+	return &Collector{
+		handler: handler,
+		scanFrequencyDesc: goprometheus.NewDesc(
+			goprometheus.BuildFQName(Namespace, "", "scan_frequency_hertz"),
+			"Scan frequency of the RPLiDAR in full rotations per second.",
+			nil,
+			constLabels,
+		),
+		coverageRatioDesc: goprometheus.NewDesc(
+			goprometheus.BuildFQName(Namespace, "", "coverage_ratio"),
+			"Fraction of the 360 angles of the current scan that have a valid measure.",
+			nil,
+			constLabels,
+		),
+		parseErrorsDesc: goprometheus.NewDesc(
+			goprometheus.BuildFQName(Namespace, "", "parse_errors_total"),
+			"Number of lines that failed to parse once the measurement data started.",
+			nil,
+			constLabels,
+		),
+		nearestObstacleDesc: goprometheus.NewDesc(
+			goprometheus.BuildFQName(Namespace, "", "nearest_obstacle_distance_millimeters"),
+			"Distance of the closest valid measure of the current scan.",
+			nil,
+			constLabels,
+		),
+		runningDesc: goprometheus.NewDesc(
+			goprometheus.BuildFQName(Namespace, "", "running"),
+			"Whether the handler is running (1) or not (0).",
+			nil,
+			constLabels,
+		),
+	}, nil
+}
+
+// Describe sends the descriptors of the metrics to the given channel.
+//
+// Parameters:
+//
+// ch: The channel to send the descriptors to.
+func (c *Collector) Describe(ch chan<- *goprometheus.Desc) {
+	ch <- c.scanFrequencyDesc
+	ch <- c.coverageRatioDesc
+	ch <- c.parseErrorsDesc
+	ch <- c.nearestObstacleDesc
+	ch <- c.runningDesc
+}
+
+// Collect sends the current values of the metrics to the given channel. The scan frequency, coverage ratio and parse
+// errors are omitted if the handler doesn't implement the StatsProvider interface, and the nearest obstacle distance is
+// omitted if it doesn't implement the ObstacleDetector interface or if the current scan has no valid measures.
+//
+// Parameters:
+//
+// ch: The channel to send the metrics to.
+func (c *Collector) Collect(ch chan<- goprometheus.Metric) {
+	// Check if the handler reports the statistics of the scans
+	if statsProvider, ok := c.handler.(gorplidarsdkhandler.StatsProvider); ok {
+		ch <- goprometheus.MustNewConstMetric(
+			c.scanFrequencyDesc,
+			goprometheus.GaugeValue,
+			statsProvider.GetScanFrequency(),
+		)
+		ch <- goprometheus.MustNewConstMetric(
+			c.coverageRatioDesc,
+			goprometheus.GaugeValue,
+			statsProvider.CoverageRatio(),
+		)
+		ch <- goprometheus.MustNewConstMetric(
+			c.parseErrorsDesc,
+			goprometheus.CounterValue,
+			float64(statsProvider.GetStats().ParseErrors),
+		)
+	}
+
+	// Check if there's a valid measure to report the nearest obstacle
+	if obstacleDetector, ok := c.handler.(gorplidarsdkhandler.ObstacleDetector); ok {
+		if _, distance, found := obstacleDetector.GetNearestObstacle(); found {
+			ch <- goprometheus.MustNewConstMetric(
+				c.nearestObstacleDesc,
+				goprometheus.GaugeValue,
+				distance,
+			)
+		}
+	}
+
+	// Report the running state
+	running := 0.0
+	if c.handler.IsRunning() {
+		running = 1
+	}
+	ch <- goprometheus.MustNewConstMetric(
+		c.runningDesc,
+		goprometheus.GaugeValue,
+		running,
+	)
+}
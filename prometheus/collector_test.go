@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	goprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	gorplidarsdkhandler "github.com/ralvarezdev/go-rplidar-sdk-handler"
+)
+
+// newTestMockHandler creates a MockHandler with two valid measures, a scan frequency and two parse errors.
+func newTestMockHandler(t *testing.T) *gorplidarsdkhandler.MockHandler {
+	t.Helper()
+	mock, err := gorplidarsdkhandler.NewMockHandler(5000, 1)
+	if err != nil {
+		t.Fatalf("failed to create the mock: %v", err)
+	}
+	mock.SetMeasure(0, 1000, 47)
+	mock.SetMeasure(90, 500, 47)
+	mock.SetScanFrequency(7.5)
+	mock.EmitParseError(errors.New("bad line"))
+	mock.EmitParseError(errors.New("bad line"))
+	return mock
+}
+
+// TestCollector checks the metrics reported for a handler with and without the optional interfaces.
+func TestCollector(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(t *testing.T) gorplidarsdkhandler.Handler
+		expected string
+	}{
+		{
+			name: "MockHandler",
+			handler: func(t *testing.T) gorplidarsdkhandler.Handler {
+				return newTestMockHandler(t)
+			},
+			expected: `
+# HELP rplidar_coverage_ratio Fraction of the 360 angles of the current scan that have a valid measure.
+# TYPE rplidar_coverage_ratio gauge
+rplidar_coverage_ratio{device="front"} 0.005555555555555556
+# HELP rplidar_nearest_obstacle_distance_millimeters Distance of the closest valid measure of the current scan.
+# TYPE rplidar_nearest_obstacle_distance_millimeters gauge
+rplidar_nearest_obstacle_distance_millimeters{device="front"} 500
+# HELP rplidar_parse_errors_total Number of lines that failed to parse once the measurement data started.
+# TYPE rplidar_parse_errors_total counter
+rplidar_parse_errors_total{device="front"} 2
+# HELP rplidar_running Whether the handler is running (1) or not (0).
+# TYPE rplidar_running gauge
+rplidar_running{device="front"} 0
+# HELP rplidar_scan_frequency_hertz Scan frequency of the RPLiDAR in full rotations per second.
+# TYPE rplidar_scan_frequency_hertz gauge
+rplidar_scan_frequency_hertz{device="front"} 7.5
+`,
+		},
+		{
+			name: "Handler without optional interfaces",
+			handler: func(t *testing.T) gorplidarsdkhandler.Handler {
+				// Hide the optional interfaces of the mock behind the bare Handler interface
+				return struct{ gorplidarsdkhandler.Handler }{newTestMockHandler(t)}
+			},
+			expected: `
+# HELP rplidar_running Whether the handler is running (1) or not (0).
+# TYPE rplidar_running gauge
+rplidar_running{device="front"} 0
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collector, err := NewCollector(test.handler(t), goprometheus.Labels{"device": "front"})
+			if err != nil {
+				t.Fatalf("failed to create the collector: %v", err)
+			}
+			if err = testutil.CollectAndCompare(collector, strings.NewReader(test.expected)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestNewCollectorNilHandler checks that a nil handler is rejected.
+func TestNewCollectorNilHandler(t *testing.T) {
+	if _, err := NewCollector(nil, nil); !errors.Is(err, gorplidarsdkhandler.ErrNilHandler) {
+		t.Errorf("expected %v, got %v", gorplidarsdkhandler.ErrNilHandler, err)
+	}
+}
@@ -0,0 +1,6 @@
+package prometheus
+
+const (
+	// Namespace is the namespace of the metrics reported by the Collector
+	Namespace = "rplidar"
+)
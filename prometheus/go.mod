@@ -0,0 +1,25 @@
+module github.com/ralvarezdev/go-rplidar-sdk-handler/prometheus
+
+go 1.25.0
+
+require (
+	github.com/prometheus/client_golang v1.24.1
+	github.com/ralvarezdev/go-rplidar-sdk-handler v0.2.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.70.1 // indirect
+	github.com/prometheus/procfs v0.21.1 // indirect
+	github.com/ralvarezdev/go-concurrent-logger v0.1.8 // indirect
+	github.com/ralvarezdev/go-context v0.1.1 // indirect
+	github.com/ralvarezdev/go-crypto v0.6.4 // indirect
+	github.com/ralvarezdev/go-strings v0.1.12 // indirect
+	golang.org/x/sync v0.21.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
@@ -0,0 +1,158 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	goconcurrentlogger "github.com/ralvarezdev/go-concurrent-logger"
+)
+
+// NewReaderHandler creates a new DefaultHandler instance that parses the ultra_simple stdout read from the given reader,
+// instead of executing ultra_simple. The reader is consumed by the first run, and if it implements io.Closer it's closed
+// when the run context is done to unblock any pending read.
+//
+// Parameters:
+//
+// r: Reader with the ultra_simple stdout, e.g. a network connection.
+// isUpsideDown: If true, the RPLiDAR is upside down, and angles will be adjusted accordingly.
+// angleAdjustment: Optional angle adjustment to apply to the angles.
+// minimumQuality: Minimum quality for a valid measurement.
+// logger: Logger instance for logging messages.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// measuresChSize: Size of the channel to send measures.
+// debug: If true, enables debug logging.
+// options: Optional settings for the handler.
+//
+// Returns:
+//
+// A pointer to a DefaultHandler instance or an error if any parameter is invalid.
+func NewReaderHandler(
+	r io.Reader,
+	isUpsideDown bool,
+	angleAdjustment float64,
+	minimumQuality int,
+	logger goconcurrentlogger.Logger,
+	maxDistanceLimit float64,
+	measuresChSize int,
+	debug bool,
+	options ...Option,
+) (*DefaultHandler, error) {
+	// Check if the reader is nil
+	if r == nil {
+		return nil, ErrNilReader
+	}
+
+	// Create the handler
+	handler, err := newHandler(
+		0,
+		"",
+		isUpsideDown,
+		angleAdjustment,
+		minimumQuality,
+		logger,
+		"",
+		maxDistanceLimit,
+		measuresChSize,
+		debug,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the measures from the reader
+	handler.runToWrapFn = func(ctx context.Context, _ context.CancelFunc) error {
+		// Reset the state of the previous run
+		handler.resetRunState()
+
+		// Log the initialization of reading measures
+		handler.handlerLoggerProducer.Info(HandlerInitializedMessage)
+		handler.signalStarted()
+
+		// Stream the reader
+		if err := handler.readerToWrap(ctx, ReaderTag, r, 0); err != nil {
+			return err
+		}
+
+		// Log the reader end
+		handler.handlerLoggerProducer.Info("RPLiDAR reader finished")
+		return nil
+	}
+	return handler, nil
+}
+
+// readerToWrap is the internal function to read the measures from a reader and process them.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+// tag: Tag to identify the source of the lines.
+// r: Reader with the ultra_simple stdout.
+// rotationPeriod: Time to pace each rotation, or 0 to process the lines as fast as they are read.
+//
+// Returns:
+//
+// An error if any issue occurs during reading or processing measures.
+func (h *DefaultHandler) readerToWrap(
+	ctx context.Context,
+	tag string,
+	r io.Reader,
+	rotationPeriod time.Duration,
+) error {
+	// Close the reader when the context is done to unblock any pending read
+	if closer, ok := r.(io.Closer); ok {
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = closer.Close()
+			case <-stopCh:
+			}
+		}()
+	}
+
+	// Pace the lines to the rotation period if required
+	lineHandler := h.handleStdoutLine
+	if rotationPeriod > 0 {
+		lastRotationAt := time.Now()
+		rotationCount := h.rotationCount.Load()
+		lineHandler = func(line string) error {
+			if err := h.handleStdoutLine(line); err != nil {
+				return err
+			}
+
+			// Check if the line completed a rotation
+			if count := h.rotationCount.Load(); count != rotationCount {
+				rotationCount = count
+
+				// Wait until the rotation period has elapsed
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(time.Until(lastRotationAt.Add(rotationPeriod))):
+				}
+				lastRotationAt = time.Now()
+			}
+			return nil
+		}
+	}
+
+	// Stream the reader
+	if err := h.scanLines(
+		ctx,
+		tag,
+		r,
+		lineHandler,
+		h.handlerLoggerProducer,
+	); err != nil && !errors.Is(err, context.Canceled) {
+		// Check if the error was caused by closing the reader after the context was done
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	goconcurrentlogger "github.com/ralvarezdev/go-concurrent-logger"
+)
+
+// NewReplayHandler creates a new DefaultHandler instance that replays the ultra_simple stdout captured in a file,
+// instead of executing ultra_simple. The lines are replayed as fast as possible unless WithReplayRotationPeriod is
+// given, every measure is kept unless WithMinimumQuality is given, and the measures channel has
+// DefaultMeasuresChannelSize slots unless WithMeasuresChannelSize is given.
+//
+// Parameters:
+//
+// path: Path to the file with the captured ultra_simple stdout.
+// logger: Logger instance for logging messages.
+// isUpsideDown: If true, the RPLiDAR was upside down, and angles will be adjusted accordingly.
+// angleAdjustment: Optional angle adjustment to apply to the angles.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// options: Optional settings for the handler.
+//
+// Returns:
+//
+// A pointer to a DefaultHandler instance or an error if any parameter is invalid.
+func NewReplayHandler(
+	path string,
+	logger goconcurrentlogger.Logger,
+	isUpsideDown bool,
+	angleAdjustment float64,
+	maxDistanceLimit float64,
+	options ...Option,
+) (*DefaultHandler, error) {
+	// Check if the replay path is empty
+	if strings.TrimSpace(path) == "" {
+		return nil, ErrEmptyReplayPath
+	}
+
+	// Create the handler
+	handler, err := newHandler(
+		0,
+		"",
+		isUpsideDown,
+		angleAdjustment,
+		0,
+		logger,
+		"",
+		maxDistanceLimit,
+		DefaultMeasuresChannelSize,
+		false,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the rotation period is valid
+	if handler.replayRotationPeriod < 0 {
+		return nil, ErrInvalidRotationPeriod
+	}
+
+	// Read the measures from the replay file
+	handler.runToWrapFn = func(ctx context.Context, _ context.CancelFunc) error {
+		return handler.replayToWrap(ctx, path, handler.replayRotationPeriod)
+	}
+	return handler, nil
+}
+
+// replayToWrap is the internal function to read the measures from a replay file and process them.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+// path: Path to the file with the captured ultra_simple stdout.
+// rotationPeriod: Time to pace each replayed rotation, or 0 to replay the lines as fast as possible.
+//
+// Returns:
+//
+// An error if any issue occurs during reading or processing measures.
+func (h *DefaultHandler) replayToWrap(
+	ctx context.Context,
+	path string,
+	rotationPeriod time.Duration,
+) error {
+	// Reset the state of the previous run
+	h.resetRunState()
+
+	// Log the initialization of reading measures
+	h.handlerLoggerProducer.Info(HandlerInitializedMessage)
+
+	// Open the replay file
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer file.Close()
+	h.signalStarted()
+
+	// Stream the replay file
+	if err = h.readerToWrap(ctx, ReplayTag, file, rotationPeriod); err != nil {
+		return err
+	}
+
+	// Log the replay end
+	h.handlerLoggerProducer.Info("RPLiDAR replay finished")
+	return nil
+}
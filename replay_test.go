@@ -0,0 +1,139 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// replayFixturePath is the path of the captured ultra_simple stdout replayed by the tests, with a banner, three full
+// rotations of one measure every 30 degrees and the sync measure of a fourth rotation. Each sync measure counts as a
+// completed rotation.
+const replayFixturePath = "testdata/replay.txt"
+
+// runTestReplay replays the fixture until its end and returns the elapsed time.
+func runTestReplay(t *testing.T, h *DefaultHandler) time.Duration {
+	t.Helper()
+	ctx, cancelFn := context.WithTimeout(context.Background(), testWaitTimeout)
+	defer cancelFn()
+
+	startedAt := time.Now()
+	if err := h.Run(ctx, cancelFn); err != nil {
+		t.Fatalf("failed to replay the fixture: %v", err)
+	}
+	return time.Since(startedAt)
+}
+
+// TestReplayHandler checks the measures, the rotation count and the device info replayed from the fixture.
+func TestReplayHandler(t *testing.T) {
+	h, err := NewReplayHandler(replayFixturePath, nopLogger{}, false, 0, 10000)
+	if err != nil {
+		t.Fatalf("failed to create the replay handler: %v", err)
+	}
+	runTestReplay(t, h)
+
+	if rotationCount := h.GetRotationCount(); rotationCount != 4 {
+		t.Errorf("expected 4 rotations, got %d", rotationCount)
+	}
+
+	// Check the measures of the last full rotation, with the sync measure of the next one at 0 degrees
+	measures := h.GetMeasures()
+	for angle := range 360 {
+		measure := measures[angle]
+		if angle%30 != 0 {
+			if measure != nil {
+				t.Errorf("expected no measure at %d degrees, got %v", angle, measure)
+			}
+			continue
+		}
+
+		expectedDistance := float64(520 + angle)
+		if angle == 0 {
+			expectedDistance = 600
+		}
+		if measure == nil {
+			t.Errorf("expected a measure at %d degrees", angle)
+			continue
+		}
+		if measure.GetDistance() != expectedDistance || measure.GetQuality() != 47 {
+			t.Errorf(
+				"expected the distance %f and quality 47 at %d degrees, got %f and %d",
+				expectedDistance,
+				angle,
+				measure.GetDistance(),
+				measure.GetQuality(),
+			)
+		}
+	}
+
+	// Check the banner of the fixture
+	if deviceInfo := h.GetDeviceInfo(); deviceInfo.SerialNumber != "5EB4E9F3C3E09CD4A7E69CF7" ||
+		deviceInfo.FirmwareVersion != "1.29" {
+		t.Errorf("unexpected device info: %+v", deviceInfo)
+	}
+}
+
+// TestReplayHandlerOptions checks the minimum quality, the upside down transform and the pacing of the replay.
+func TestReplayHandlerOptions(t *testing.T) {
+	rotationPeriod := 20 * time.Millisecond
+	h, err := NewReplayHandler(
+		replayFixturePath,
+		nopLogger{},
+		true,
+		0,
+		10000,
+		WithReplayRotationPeriod(rotationPeriod),
+		WithMinimumQuality(48),
+		WithMeasuresChannelSize(1),
+	)
+	if err != nil {
+		t.Fatalf("failed to create the replay handler: %v", err)
+	}
+
+	// Check that each rotation after the first one waited for the rotation period
+	if elapsed := runTestReplay(t, h); elapsed < 3*rotationPeriod {
+		t.Errorf("expected the replay to take at least %s, got %s", 3*rotationPeriod, elapsed)
+	}
+
+	// Check that the measures below the minimum quality were dropped
+	if coverage := h.CoverageRatio(); coverage != 0 {
+		t.Errorf("expected no stored measure, got a coverage of %f", coverage)
+	}
+	if qualityDropped := h.GetStats().QualityDropped; qualityDropped == 0 {
+		t.Error("expected the measures to be dropped by their quality")
+	}
+}
+
+// TestNewReplayHandlerErrors checks the errors returned for invalid parameters and options.
+func TestNewReplayHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		options     []Option
+		expectedErr error
+	}{
+		{name: "empty path", path: " ", expectedErr: ErrEmptyReplayPath},
+		{
+			name:        "negative rotation period",
+			path:        replayFixturePath,
+			options:     []Option{WithReplayRotationPeriod(-time.Second)},
+			expectedErr: ErrInvalidRotationPeriod,
+		},
+		{
+			name:        "zero measures channel size",
+			path:        replayFixturePath,
+			options:     []Option{WithMeasuresChannelSize(0)},
+			expectedErr: ErrInvalidMeasuresChannelSize,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewReplayHandler(test.path, nopLogger{}, false, 0, 10000, test.options...)
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("expected %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
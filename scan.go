@@ -0,0 +1,405 @@
+package go_rplidar_sdk_handler
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type (
+	// Scan is an immutable snapshot of the measures of a RPLiDAR, which can be analyzed without holding the handler.
+	Scan struct {
+		measures         [360]*Measure
+		maxDistanceLimit float64
+		timestamp        time.Time
+	}
+)
+
+// NewScan creates a new Scan instance from a copy of the given measures.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+//
+// Returns:
+//
+// A pointer to a Scan instance or an error if any parameter is invalid.
+func NewScan(measures *[360]*Measure, maxDistanceLimit float64) (*Scan, error) {
+	// Check if the max distance limit is valid
+	if maxDistanceLimit <= 0 {
+		return nil, ErrInvalidMaxDistanceLimit
+	}
+
+	return &Scan{
+		measures:         *measures,
+		maxDistanceLimit: maxDistanceLimit,
+		timestamp:        time.Now(),
+	}, nil
+}
+
+// GetMeasures returns a copy of the measures of the scan.
+//
+// Returns:
+//
+// A copy of the measures of the scan.
+func (s *Scan) GetMeasures() *[360]*Measure {
+	measuresCopy := s.measures
+	return &measuresCopy
+}
+
+// GetMaxDistanceLimit returns the maximum distance limit for valid measurements of the scan.
+//
+// Returns:
+//
+// The maximum distance limit.
+func (s *Scan) GetMaxDistanceLimit() float64 {
+	return s.maxDistanceLimit
+}
+
+// GetTimestamp returns the time at which the scan was captured.
+//
+// Returns:
+//
+// The timestamp of the scan.
+func (s *Scan) GetTimestamp() time.Time {
+	return s.timestamp
+}
+
+// MarshalJSON returns the JSON representation of the scan, as the array of 360 measures indexed by angle.
+//
+// Returns:
+//
+// The JSON encoded measures, or an error if they couldn't be encoded.
+func (s *Scan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.measures)
+}
+
+// WriteCSV writes the measures of the scan as CSV, one row per non-nil measure sorted by angle.
+//
+// Parameters:
+//
+// w: The writer to write the CSV to.
+//
+// Returns:
+//
+// An error if the CSV couldn't be written.
+func (s *Scan) WriteCSV(w io.Writer) error {
+	return WriteScanCSV(w, &s.measures)
+}
+
+// GetValidMeasures returns the valid measures of the scan.
+//
+// Returns:
+//
+// The valid measures sorted by angle.
+func (s *Scan) GetValidMeasures() []*Measure {
+	return GetValidMeasures(&s.measures, s.maxDistanceLimit)
+}
+
+// HasMeasureAt checks if the given angle of the scan has a valid measure.
+//
+// Parameters:
+//
+// angle: The angle to check in degrees.
+//
+// Returns:
+//
+// True if the angle is within [0, 360) and has a valid measure, false otherwise.
+func (s *Scan) HasMeasureAt(angle int) bool {
+	// Check if the angle is valid
+	if angle < 0 || angle >= 360 {
+		return false
+	}
+	return isValidMeasure(s.measures[angle], s.maxDistanceLimit)
+}
+
+// CoverageRatio calculates the fraction of the 360 angles of the scan that have a valid measure.
+//
+// Returns:
+//
+// The fraction of angles with a valid measure, in [0, 1].
+func (s *Scan) CoverageRatio() float64 {
+	return CoverageRatio(&s.measures, s.maxDistanceLimit)
+}
+
+// PointCloud returns the valid measures of the scan as Cartesian points.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func (s *Scan) PointCloud() [][2]float64 {
+	return pointCloud(s.measures[:], s.maxDistanceLimit)
+}
+
+// NearestObstacle finds the closest valid measure of the scan.
+//
+// Returns:
+//
+// The angle and distance of the closest valid measure, and false if there are no valid measures.
+func (s *Scan) NearestObstacle() (angle int, distance float64, ok bool) {
+	return GetNearestObstacle(&s.measures, s.maxDistanceLimit)
+}
+
+// FarthestValidDistance finds the valid measure with the most clearance of the scan.
+//
+// Returns:
+//
+// The angle and distance of the farthest valid measure, and false if there are no valid measures.
+func (s *Scan) FarthestValidDistance() (angle int, distance float64, ok bool) {
+	return GetFarthestValidDistance(&s.measures, s.maxDistanceLimit)
+}
+
+// Clusters groups the contiguous valid measures of the scan with similar distances.
+//
+// Parameters:
+//
+// maxGapDeg: The maximum angular gap in degrees between two measures of the same cluster.
+// maxDistJumpMm: The maximum distance jump in millimeters between two measures of the same cluster.
+//
+// Returns:
+//
+// The clusters sorted by their start angle.
+func (s *Scan) Clusters(maxGapDeg int, maxDistJumpMm float64) []Cluster {
+	return DetectClusters(&s.measures, s.maxDistanceLimit, maxGapDeg, maxDistJumpMm)
+}
+
+// FreeCorridorWidth expands from the heading to both sides of the scan until it hits a measure closer than the
+// clearance.
+//
+// Parameters:
+//
+// heading: The heading angle in degrees.
+// clearanceMm: The clearance in millimeters.
+//
+// Returns:
+//
+// The last clear angles at the left and at the right of the heading, or NoCorridorAngle at both sides if the heading or
+// the clearance is not valid, or if the heading itself is blocked.
+func (s *Scan) FreeCorridorWidth(heading int, clearanceMm float64) (leftAngle, rightAngle int) {
+	return FreeCorridorWidth(&s.measures, s.maxDistanceLimit, heading, clearanceMm)
+}
+
+// Downsampled reduces the scan to the nearest valid measure of each sector of step degrees.
+//
+// Parameters:
+//
+// step: The width of each sector in degrees.
+//
+// Returns:
+//
+// A slice with the representative of each sector, which is nil if the sector has no valid measures, or an error if the
+// step is not valid.
+func (s *Scan) Downsampled(step int) ([]*Measure, error) {
+	return GetDownsampledScan(&s.measures, s.maxDistanceLimit, step)
+}
+
+// Interpolated fills the small gaps of the scan by linearly interpolating the nearest valid measures.
+//
+// Parameters:
+//
+// maxGap: The maximum number of consecutive missing angles to fill.
+//
+// Returns:
+//
+// A new scan with the small gaps filled.
+func (s *Scan) Interpolated(maxGap int) *Scan {
+	return &Scan{
+		measures:         *GetInterpolatedScan(&s.measures, s.maxDistanceLimit, maxGap),
+		maxDistanceLimit: s.maxDistanceLimit,
+		timestamp:        s.timestamp,
+	}
+}
+
+// PolarHistogram calculates the obstacle density of each sector of sectorDeg degrees of the scan.
+//
+// Parameters:
+//
+// sectorDeg: The width of each sector in degrees.
+// thresholdMm: The distance in millimeters under which a measure is considered an obstacle.
+//
+// Returns:
+//
+// The obstacle density of each sector in clockwise order starting at 0 degrees, or an error if the sector width or the
+// threshold is not valid.
+func (s *Scan) PolarHistogram(sectorDeg int, thresholdMm float64) ([]float64, error) {
+	return GetPolarHistogram(&s.measures, s.maxDistanceLimit, sectorDeg, thresholdMm)
+}
+
+// AverageDistanceFromAngle calculates the average distance of the scan for a given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the average distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The average distance for the specified angle, or an error if the angle is not valid.
+func (s *Scan) AverageDistanceFromAngle(middleAngle int, width int) (float64, error) {
+	return GetAverageDistanceFromAngle(&s.measures, middleAngle, width)
+}
+
+// MedianDistanceFromAngle calculates the median distance of the scan for a given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the median distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The median distance for the specified angle, or an error if the angle is not valid.
+func (s *Scan) MedianDistanceFromAngle(middleAngle int, width int) (float64, error) {
+	return GetMedianDistanceFromAngle(&s.measures, middleAngle, width)
+}
+
+// IsObstacleWithin checks if the scan has a valid measure within the given distance inside a window of angles.
+//
+// Parameters:
+//
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// maxMm: The distance in millimeters within which a measure is an obstacle.
+//
+// Returns:
+//
+// True if a valid measure within the distance is found, or false if there's none or the window is not valid.
+func (s *Scan) IsObstacleWithin(middleAngle int, width int, maxMm float64) bool {
+	return IsObstacleWithin(&s.measures, middleAngle, width, maxMm)
+}
+
+// QualityWeightedAverageFromAngle calculates the average distance of the scan for a given angle, weighting each
+// distance by its quality.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the average distance for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The quality-weighted average distance for the specified angle, or an error if the angle is not valid.
+func (s *Scan) QualityWeightedAverageFromAngle(middleAngle int, width int) (float64, error) {
+	return GetQualityWeightedAverageFromAngle(&s.measures, middleAngle, width)
+}
+
+// DistanceStdDevFromAngle calculates the mean and the sample standard deviation of the distances of the scan for a
+// given angle.
+//
+// Parameters:
+//
+// middleAngle: The middle angle to calculate the standard deviation for.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The mean and the sample standard deviation of the distances for the specified angle, or an error if the angle is not
+// valid.
+func (s *Scan) DistanceStdDevFromAngle(middleAngle int, width int) (mean, stddev float64, err error) {
+	return GetDistanceStdDevFromAngle(&s.measures, middleAngle, width)
+}
+
+// AverageDistanceInRange calculates the average distance of the scan from the start angle to the end angle inclusive.
+//
+// Parameters:
+//
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range, which wraps around the 0/360 seam if it's less than the start angle.
+//
+// Returns:
+//
+// The average distance for the specified range, or an error if any angle is not valid.
+func (s *Scan) AverageDistanceInRange(startAngle int, endAngle int) (float64, error) {
+	return GetAverageDistanceInRange(&s.measures, startAngle, endAngle)
+}
+
+// AverageDistanceFromDirection calculates the average distance of the scan for a given direction.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction is not valid.
+func (s *Scan) AverageDistanceFromDirection(width int, direction CardinalDirection) (float64, error) {
+	return GetAverageDistanceFromDirection(&s.measures, width, direction)
+}
+
+// MeasuresInDirection collects the valid measures of the scan within the window centered on a given direction.
+//
+// Parameters:
+//
+// direction: The direction to center the window on.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The valid measures within the window, or an error if the direction or the width is not valid.
+func (s *Scan) MeasuresInDirection(direction CardinalDirection, width int) ([]*Measure, error) {
+	return GetMeasuresInDirection(&s.measures, direction, width)
+}
+
+// AverageDistancesFromDirections calculates the average distances of the scan for the specified directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// directions: The directions to calculate the average distances for.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction is not valid. The
+// directions without valid measures are omitted from the map.
+func (s *Scan) AverageDistancesFromDirections(
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromDirections(&s.measures, width, directions...)
+}
+
+// AverageDistancesFromAllDirections calculates the average distances of the scan for all cardinal directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not
+// valid. The directions without valid measures are omitted from the map.
+func (s *Scan) AverageDistancesFromAllDirections(width int) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromAllDirections(&s.measures, width)
+}
+
+// AverageDistancesFromPrimaryDirections calculates the average distances of the scan for the 8 primary cardinal
+// directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with the primary cardinal directions as keys and their average distances as values, or an error if the width is
+// not valid. The directions without valid measures are omitted from the map.
+func (s *Scan) AverageDistancesFromPrimaryDirections(width int) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromPrimaryDirections(&s.measures, width)
+}
+
+// AverageDistancesWithWidths calculates the average distances of the scan for the specified directions, each one with
+// its own width.
+//
+// Parameters:
+//
+// widths: A map with the directions as keys and the sum of the angles to consider around them as values.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction or width is not
+// valid. The directions without valid measures are omitted from the map.
+func (s *Scan) AverageDistancesWithWidths(widths map[CardinalDirection]int) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesWithWidths(&s.measures, widths)
+}
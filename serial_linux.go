@@ -0,0 +1,17 @@
+//go:build linux
+
+package go_rplidar_sdk_handler
+
+var (
+	// serialPortPatterns are the glob patterns of the devices likely to be a RPLiDAR on Linux
+	serialPortPatterns = []string{"/dev/ttyUSB*", "/dev/ttyACM*"}
+)
+
+// ListSerialPorts lists the serial ports likely to be a RPLiDAR device, without opening them.
+//
+// Returns:
+//
+// The sorted serial port paths, or an error if they couldn't be listed.
+func ListSerialPorts() ([]string, error) {
+	return globSerialPorts(serialPortPatterns)
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !windows
+
+package go_rplidar_sdk_handler
+
+var (
+	// serialPortPatterns are the glob patterns of the devices likely to be a RPLiDAR on macOS and the BSDs
+	serialPortPatterns = []string{"/dev/cu.usbserial*", "/dev/cu.SLAB_USBtoUART*", "/dev/cu.usbmodem*", "/dev/ttyU*"}
+)
+
+// ListSerialPorts lists the serial ports likely to be a RPLiDAR device, without opening them.
+//
+// Returns:
+//
+// The sorted serial port paths, or an error if they couldn't be listed.
+func ListSerialPorts() ([]string, error) {
+	return globSerialPorts(serialPortPatterns)
+}
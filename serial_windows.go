@@ -0,0 +1,42 @@
+//go:build windows
+
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+)
+
+var (
+	// serialPortRegexp matches the COM port names in the serial devices registry key
+	serialPortRegexp = regexp.MustCompile(`\bCOM\d+\b`)
+)
+
+// ListSerialPorts lists the COM ports registered on Windows, without opening them.
+//
+// Returns:
+//
+// The sorted COM port names, or an error if they couldn't be listed.
+func ListSerialPorts() ([]string, error) {
+	// Query the serial devices registry key
+	output, err := exec.Command(
+		"reg",
+		"query",
+		`HKLM\HARDWARE\DEVICEMAP\SERIALCOMM`,
+	).Output()
+	if err != nil {
+		// The key doesn't exist if there are no serial devices
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query serial ports: %w", err)
+	}
+
+	ports := serialPortRegexp.FindAllString(string(output), -1)
+	sort.Strings(ports)
+	return ports, nil
+}
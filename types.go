@@ -2,9 +2,13 @@ package go_rplidar_sdk_handler
 
 import (
 	"bufio"
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"os/exec"
 	"strconv"
@@ -22,37 +26,275 @@ import (
 type (
 	// Measure is a struct that represents a single measurement from the RPLiDAR.
 	Measure struct {
-		angle      float64
-		distance   float64
-		quality    int
-		hasSyncBit bool
+		angle          float64
+		distance       float64
+		quality        int
+		hasSyncBit     bool
+		timestamp      time.Time
+		isInterpolated bool
+		rawAngle       float64
+		qualityScale   int
+	}
+
+	// measureJSON is the JSON representation of a Measure.
+	measureJSON struct {
+		Angle        float64 `json:"angle"`
+		Distance     float64 `json:"distance"`
+		Quality      int     `json:"quality"`
+		SyncBit      bool    `json:"syncBit"`
+		Interpolated bool    `json:"interpolated,omitempty"`
+	}
+
+	// RetryPolicy is the policy used to relaunch ultra_simple after it exits unexpectedly.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts, or 0 to retry until the context is cancelled
+		MaxAttempts int
+
+		// InitialBackoff is the delay before the first retry
+		InitialBackoff time.Duration
+
+		// MaxBackoff is the maximum delay between retries
+		MaxBackoff time.Duration
+
+		// Multiplier is the factor applied to the delay after each retry
+		Multiplier float64
+	}
+
+	// ParserConfig is the layout of the measure lines printed by ultra_simple.
+	ParserConfig struct {
+		// AngleIndex is the index of the angle field
+		AngleIndex int
+
+		// DistanceIndex is the index of the distance field
+		DistanceIndex int
+
+		// QualityIndex is the index of the quality field
+		QualityIndex int
+
+		// Separator is the separator of the fields, or empty to split them on whitespace only
+		Separator string
+	}
+
+	// AngleRange is a range of angles in degrees from Start to End inclusive, which crosses the 0/360 seam if Start is
+	// greater than End.
+	AngleRange struct {
+		// Start is the first angle of the range
+		Start float64
+
+		// End is the last angle of the range
+		End float64
+	}
+
+	// HandlerStats is a snapshot of the line and measure counters of the current run.
+	HandlerStats struct {
+		// LinesRead is the number of stdout lines read
+		LinesRead uint64
+
+		// MeasuresParsed is the number of lines successfully parsed as measures
+		MeasuresParsed uint64
+
+		// ParseErrors is the number of lines that failed to parse once the measurement data started
+		ParseErrors uint64
+
+		// OutOfRangeDropped is the number of measures dropped due to an invalid distance or a distance within the dead zone
+		OutOfRangeDropped uint64
+
+		// QualityDropped is the number of measures dropped due to a quality below the minimum quality
+		QualityDropped uint64
+
+		// MaskedDropped is the number of measures dropped due to an angle within a masked range
+		MaskedDropped uint64
+
+		// RotationsCompleted is the number of full rotations completed
+		RotationsCompleted uint64
+	}
+
+	// RotationCompleted is the event emitted each time the RPLiDAR completes a full rotation.
+	RotationCompleted struct{}
+
+	// MountPose is the pose of a RPLiDAR relative to the robot frame, whose origin is the rotation center of the robot.
+	MountPose struct {
+		// OffsetX is the offset in millimeters to the right of the robot frame origin
+		OffsetX float64
+
+		// OffsetY is the offset in millimeters ahead of the robot frame origin
+		OffsetY float64
+
+		// Yaw is the clockwise rotation in degrees of the RPLiDAR relative to the robot forward direction
+		Yaw float64
+	}
+
+	// Cluster is a group of contiguous valid measures with similar distances, usually belonging to the same object.
+	Cluster struct {
+		// StartAngle is the first angle of the cluster, which is greater than EndAngle if it crosses the 0/360 seam
+		StartAngle int
+
+		// EndAngle is the last angle of the cluster
+		EndAngle int
+
+		// MeanDistance is the mean distance of the measures of the cluster
+		MeanDistance float64
+
+		// MinDistance is the distance of the closest measure of the cluster
+		MinDistance float64
+
+		// count is the number of measures of the cluster
+		count int
+
+		// firstDistance is the distance of the first measure of the cluster
+		firstDistance float64
+
+		// lastDistance is the distance of the last measure of the cluster
+		lastDistance float64
+	}
+
+	// Obstacle is the angle and distance of a measure that obstructs the RPLiDAR.
+	Obstacle struct {
+		// Angle is the angle of the obstacle in degrees
+		Angle int
+
+		// Distance is the distance of the obstacle
+		Distance float64
+	}
+
+	// MotionEvent is a change of the distance of an angle between two consecutive scans.
+	MotionEvent struct {
+		// Angle is the angle in degrees where the distance changed
+		Angle int
+
+		// PreviousDistance is the distance of the angle in the previous scan
+		PreviousDistance float64
+
+		// CurrentDistance is the distance of the angle in the current scan
+		CurrentDistance float64
+
+		// Kind is whether the obstacle at the angle is approaching or receding
+		Kind MotionKind
+	}
+
+	// ScanResult is a consistent snapshot of the current scan with its metadata, all captured at the same moment.
+	ScanResult struct {
+		// Measures are the measures of the scan indexed by angle
+		Measures [360]*Measure
+
+		// Timestamp is the time at which the scan was captured
+		Timestamp time.Time
+
+		// Coverage is the fraction of the 360 angles that have a valid measure
+		Coverage float64
+
+		// Frequency is the scan frequency in Hz at the time of the capture
+		Frequency float64
+
+		// NearestObstacle is the closest valid measure, or nil if there are no valid measures
+		NearestObstacle *Obstacle
 	}
 
 	// DefaultHandler is the handler for the Slamtec RPLiDAR devices
 	DefaultHandler struct {
-		handlerMutex          sync.Mutex
-		measuresMutex         sync.RWMutex
-		isRunning             atomic.Bool
-		logger                goconcurrentlogger.Logger
-		handlerLoggerProducer goconcurrentlogger.LoggerProducer
-		baudRate              int
-		isUpsideDown          bool
-		angleAdjustment       float64
-		measures              [360]*Measure
-		minimumQuality        int
-		stdoutLinesRead       int
-		ultraSimplePath       string
-		maxDistanceLimit      float64
-		port                  string
-		debug                 bool
-		hasStartedSending     atomic.Bool
-		measuresChSize        int
-		measuresCh            chan *Measure
-		readyCh			  chan struct{}
+		handlerMutex              sync.Mutex
+		measuresMutex             sync.RWMutex
+		isRunning                 atomic.Bool
+		logger                    goconcurrentlogger.Logger
+		handlerLoggerProducer     goconcurrentlogger.LoggerProducer
+		baudRate                  int
+		isUpsideDown              bool
+		angleAdjustment           float64
+		measures                  [360]*Measure
+		minimumQuality            int
+		stdoutLinesRead           int
+		isReceivingMeasures       bool
+		ultraSimplePath           string
+		maxDistanceLimit          float64
+		port                      string
+		debug                     bool
+		hasStartedSending         atomic.Bool
+		measuresChSize            int
+		measuresCh                chan *Measure
+		readyCh                   chan struct{}
 		rplidarApplicationStarted atomic.Bool
+		doneCh                    chan struct{}
+		subscribersMutex          sync.Mutex
+		subscribers               map[chan *Measure]struct{}
+		subscriberBufferSize      int
+		subscriberOverflowPolicy  SubscriberOverflowPolicy
+		rotationEventsCh          chan RotationCompleted
+		ignoreFirstStdoutMessages int
+		rotationsMutex            sync.Mutex
+		rotationTimestamps        [ScanFrequencySamples]time.Time
+		rotationTimestampsCount   int
+		rotationTimestampsIndex   int
+		rotationCount             atomic.Uint64
+		linesRead                 atomic.Uint64
+		measuresParsed            atomic.Uint64
+		parseErrors               atomic.Uint64
+		outOfRangeDropped         atomic.Uint64
+		qualityDropped            atomic.Uint64
+		maskedDropped             atomic.Uint64
+		runToWrapFn               func(ctx context.Context, cancelFn context.CancelFunc) error
+		extraArgs                 []string
+		closeTimeout              time.Duration
+		stderrMutex               sync.Mutex
+		bucketsPerDegree          int
+		fineMeasures              []*Measure
+		accumulateMeasures        bool
+		accumulatedMeasures       [360][]*Measure
+		measureTTL                time.Duration
+		clock                     func() time.Time
+		healthMaxDataAge          time.Duration
+		healthMinScanFrequency    float64
+		healthMinCoverage         float64
+		minDistanceLimit          float64
+		recentStderr              []string
+		callbacksMutex            sync.Mutex
+		onRotationComplete        func(scan *[360]*Measure)
+		eventSink                 EventSink
+		dataTimeout               time.Duration
+		replayRotationPeriod      time.Duration
+		lastMeasureAt             atomic.Int64
+		mountPose                 MountPose
+		initialBufferSize         int
+		maxBufferSize             int
+		maxLineLength             int
+		parseErrorsCh             chan error
+		runCancelFn               context.CancelFunc
+		onStarted                 func()
+		runWaitCh                 chan struct{}
+		runErr                    error
+		skipPortCheck             bool
+		parserConfig              ParserConfig
+		deviceInfoMutex           sync.Mutex
+		deviceInfo                DeviceInfo
+		failOnUnhealthyDevice     bool
+		smoothingAlpha            float64
+		scanHistoryMutex          sync.Mutex
+		scanHistory               []*Scan
+		scanHistorySize           int
+		scanHistoryCount          int
+		scanHistoryIndex          int
+		captureWriter             io.Writer
+		captureMutex              sync.Mutex
+		captureCh                 chan string
+		maskedRanges              []AngleRange
+		qualityScale              int
+		isClosed                  atomic.Bool
+		parserWorkers             int
+		measurePool               *sync.Pool
 	}
 )
 
+// Check that DefaultHandler implements the Handler interface and its optional interfaces
+var (
+	_ Handler            = (*DefaultHandler)(nil)
+	_ Subscriber         = (*DefaultHandler)(nil)
+	_ RotationNotifier   = (*DefaultHandler)(nil)
+	_ ParseErrorNotifier = (*DefaultHandler)(nil)
+	_ StatsProvider      = (*DefaultHandler)(nil)
+	_ HealthChecker      = (*DefaultHandler)(nil)
+	_ PointCloudProvider = (*DefaultHandler)(nil)
+	_ ObstacleDetector   = (*DefaultHandler)(nil)
+)
+
 // validateAngle validates the angle value.
 //
 // Parameters:
@@ -68,13 +310,15 @@ func validateAngle(angle float64, hasSyncBit bool) error {
 	if !hasSyncBit {
 		if angle < 0 || angle >= 360 {
 			return fmt.Errorf(
-				"angle without sync bit must be in [0, 360), got %f",
+				"%w: got %f for a measure without sync bit",
+				ErrInvalidAngle,
 				angle,
 			)
 		}
 	} else if angle < 0 {
 		return fmt.Errorf(
-			"angle with sync bit must be non-negative, got %f",
+			"%w: got %f for a measure with sync bit, which must be non-negative",
+			ErrInvalidAngle,
 			angle,
 		)
 	}
@@ -83,6 +327,10 @@ func validateAngle(angle float64, hasSyncBit bool) error {
 
 // NewMeasure creates a new Measure instance.
 //
+// A whole angle adjustment, such as the 90 or 180 degrees of most mounts, rotates the degree of the angle as an index,
+// so a measure reported within a degree always lands exactly on the rotated degree. A fractional adjustment is added to
+// the angle instead, so it can move part of the measures of a degree to the next one.
+//
 // Parameters:
 //
 // angle: Angle of the measurement in degrees.
@@ -94,7 +342,7 @@ func validateAngle(angle float64, hasSyncBit bool) error {
 //
 // Returns:
 //
-// A Measure instance, or an error if any parameter is invalid.
+// A Measure instance timestamped with the host receive time, or an error if any parameter is invalid.
 func NewMeasure(
 	angle, distance float64,
 	quality int,
@@ -102,42 +350,188 @@ func NewMeasure(
 	isUpsideDown bool,
 	angleAdjustment float64,
 ) (*Measure, error) {
-	// Validate angle
-	if err := validateAngle(angle, hasSyncBit); err != nil {
+	measure := &Measure{}
+	if err := initMeasure(
+		measure,
+		angle,
+		distance,
+		quality,
+		hasSyncBit,
+		isUpsideDown,
+		angleAdjustment,
+	); err != nil {
 		return nil, err
 	}
+	return measure, nil
+}
 
-	// Ensure the angle is between 0 and 360 if it has a sync bit
-	if hasSyncBit {
-		angle = angle - 360.0
+// initMeasure initializes every field of the given Measure instance, so a pooled instance can be reused.
+//
+// Parameters:
+//
+// measure: The Measure instance to initialize.
+// angle: Angle of the measurement in degrees.
+// distance: Distance of the measurement in millimeters.
+// quality: Quality of the measurement.
+// hasSyncBit: Indicates if the measurement has a sync bit.
+// isUpsideDown: Indicates if the LIDAR is upside down.
+// angleAdjustment: Angle adjustment to apply to the angle.
+//
+// Returns:
+//
+// An error if any parameter is invalid.
+func initMeasure(
+	measure *Measure,
+	angle, distance float64,
+	quality int,
+	hasSyncBit bool,
+	isUpsideDown bool,
+	angleAdjustment float64,
+) error {
+	// Validate angle
+	if err := validateAngle(angle, hasSyncBit); err != nil {
+		return err
 	}
 
+	// Keep the angle reported by the RPLiDAR before any transform
+	rawAngle := angle
+
+	// The sync bit only marks the start of a rotation, so it doesn't change the reported angle
+
 	// Adjust angle if the LIDAR is upside down
 	if isUpsideDown {
 		angle = 360.0 - angle
 	}
 
-	// Apply angle adjustment
-	if angleAdjustment != 0 {
-		angle = angle + angleAdjustment
-	}
-
-	// Normalize angle to be within [0, 360)
-	if angle < 0 {
-		angle = angle + 360.0
-	} else if angle >= 360.0 {
-		angle = angle - 360.0
+	// Apply angle adjustment, rotating the degree as an index if it's a whole number of degrees to avoid any float drift
+	if !math.IsInf(angleAdjustment, 0) && angleAdjustment == math.Trunc(angleAdjustment) {
+		angle = rotateAngleByDegrees(angle, int(math.Mod(angleAdjustment, 360.0)))
+	} else {
+		angle = normalizeAngle(angle + angleAdjustment)
 	}
 
-	return &Measure{
+	*measure = Measure{
 		angle:      angle,
 		distance:   distance,
 		quality:    quality,
 		hasSyncBit: hasSyncBit,
-	}, nil
+		timestamp:  time.Now(),
+		rawAngle:   rawAngle,
+	}
+	return nil
+}
+
+// stripMeasureLabels removes the known label tokens from the fields of a measure string, such as "theta:", "Dist:" and
+// "Q:", whether they are separate tokens or attached to the value.
+//
+// Parameters:
+//
+// fields: The fields of the measure string.
+//
+// Returns:
+//
+// The fields without the label tokens.
+func stripMeasureLabels(fields []string) []string {
+	strippedFields := fields[:0]
+	for _, field := range fields {
+		lowerField := strings.ToLower(field)
+		for _, label := range MeasureLabels {
+			if strings.HasPrefix(lowerField, label) {
+				field = field[len(label):]
+				break
+			}
+		}
+
+		// Skip the standalone label tokens
+		if field == "" {
+			continue
+		}
+		strippedFields = append(strippedFields, field)
+	}
+	return strippedFields
+}
+
+// stripSyncBit removes the sync bit marker from the fields of a measure string, whether it's a standalone token at any
+// position, or attached to the start of the first field or to the end of the last field.
+//
+// Parameters:
+//
+// fields: The fields of the measure string.
+//
+// Returns:
+//
+// The fields without the sync bit marker, and true if the marker was found.
+func stripSyncBit(fields []string) ([]string, bool) {
+	hasSyncBit := false
+	strippedFields := fields[:0]
+	for index, field := range fields {
+		// Skip the standalone sync bit token
+		if field == SyncBitCharacter {
+			hasSyncBit = true
+			continue
+		}
+
+		// Check if the sync bit is attached to the first or the last field
+		if index == 0 && strings.HasPrefix(field, SyncBitCharacter) {
+			field = field[len(SyncBitCharacter):]
+			hasSyncBit = true
+		} else if index == len(fields)-1 && strings.HasSuffix(field, SyncBitCharacter) {
+			field = field[:len(field)-len(SyncBitCharacter)]
+			hasSyncBit = true
+		}
+		strippedFields = append(strippedFields, field)
+	}
+	return strippedFields, hasSyncBit
+}
+
+// Validate checks if the parser config is valid.
+//
+// Returns:
+//
+// An error if any field index is negative or if two fields share the same index.
+func (p ParserConfig) Validate() error {
+	if p.AngleIndex < 0 || p.DistanceIndex < 0 || p.QualityIndex < 0 {
+		return fmt.Errorf("%w: field indices cannot be negative", ErrInvalidParserConfig)
+	}
+	if p.AngleIndex == p.DistanceIndex || p.AngleIndex == p.QualityIndex || p.DistanceIndex == p.QualityIndex {
+		return fmt.Errorf("%w: field indices must be different", ErrInvalidParserConfig)
+	}
+	return nil
+}
+
+// fieldCount returns the number of fields of a measure line, which is the highest field index plus one.
+//
+// Returns:
+//
+// The number of fields of a measure line.
+func (p ParserConfig) fieldCount() int {
+	return max(p.AngleIndex, p.DistanceIndex, p.QualityIndex) + 1
+}
+
+// splitFields splits a measure line into its fields, splitting on the separator if set, and on whitespace.
+//
+// Parameters:
+//
+// measureStr: String representation of the measurement.
+//
+// Returns:
+//
+// The non-empty fields of the measure line.
+func (p ParserConfig) splitFields(measureStr string) []string {
+	// Check if the fields are only separated by whitespace
+	if p.Separator == "" {
+		return strings.Fields(measureStr)
+	}
+
+	var fields []string
+	for _, part := range strings.Split(measureStr, p.Separator) {
+		fields = append(fields, strings.Fields(part)...)
+	}
+	return fields
 }
 
-// NewMeasureFromString creates a new Measure instance from a string representation of the measurement.
+// NewMeasureFromString creates a new Measure instance from a string representation of the measurement, using the
+// default parser config.
 //
 // Parameters:
 //
@@ -147,54 +541,155 @@ func NewMeasure(
 //
 // Returns:
 //
-// A Measure instance, or an error if the string is invalid.
+// A Measure instance, or an error wrapping ErrMeasureFieldCount, ErrParseAngle, ErrParseDistance, ErrParseQuality or
+// ErrInvalidAngle if the string is invalid.
 func NewMeasureFromString(
 	measureStr string,
 	isUpsideDown bool,
 	angleAdjustment float64,
 ) (*Measure, error) {
-	// Trim and split
-	fields := strings.Fields(measureStr)
+	return NewMeasureFromStringWithConfig(
+		measureStr,
+		DefaultParserConfig,
+		isUpsideDown,
+		angleAdjustment,
+	)
+}
 
-	// Check if it has sync bit
-	hasSyncBit := false
-	if len(fields) == 4 && fields[0] == SyncBitCharacter {
-		hasSyncBit = true
-		fields = fields[1:]
+// NewMeasureFromStringWithConfig creates a new Measure instance from a string representation of the measurement, whose
+// layout is described by the given parser config.
+//
+// Parameters:
+//
+// measureStr: String representation of the measurement.
+// parserConfig: Layout of the measure string.
+// isUpsideDown: Indicates if the RPLiDAR is upside down.
+// angleAdjustment: Angle adjustment to apply to the angle.
+//
+// Returns:
+//
+// A Measure instance, or an error wrapping ErrMeasureFieldCount, ErrParseAngle, ErrParseDistance, ErrParseQuality or
+// ErrInvalidAngle if the string is invalid.
+func NewMeasureFromStringWithConfig(
+	measureStr string,
+	parserConfig ParserConfig,
+	isUpsideDown bool,
+	angleAdjustment float64,
+) (*Measure, error) {
+	measure := &Measure{}
+	if err := parseMeasureInto(
+		measure,
+		measureStr,
+		parserConfig,
+		isUpsideDown,
+		angleAdjustment,
+	); err != nil {
+		return nil, err
+	}
+	return measure, nil
+}
+
+// parseMeasureInto initializes the given Measure instance from a string representation of the measurement, so a
+// pooled instance can be reused.
+//
+// Parameters:
+//
+// measure: The Measure instance to initialize.
+// measureStr: String representation of the measurement.
+// parserConfig: Layout of the measure string.
+// isUpsideDown: Indicates if the RPLiDAR is upside down.
+// angleAdjustment: Angle adjustment to apply to the angle.
+//
+// Returns:
+//
+// An error wrapping ErrMeasureFieldCount, ErrParseAngle, ErrParseDistance, ErrParseQuality or ErrInvalidAngle if the
+// string is invalid.
+func parseMeasureInto(
+	measure *Measure,
+	measureStr string,
+	parserConfig ParserConfig,
+	isUpsideDown bool,
+	angleAdjustment float64,
+) error {
+	// Try the allocation-free path first, the flexible path handles the labelled formats and reports the errors
+	if parsePlainMeasure(
+		measure,
+		measureStr,
+		parserConfig,
+		isUpsideDown,
+		angleAdjustment,
+	) {
+		return nil
 	}
+	return parseFlexibleMeasure(
+		measure,
+		measureStr,
+		parserConfig,
+		isUpsideDown,
+		angleAdjustment,
+	)
+}
+
+// parseFlexibleMeasure parses a measure string with any of the supported formats, such as the labelled one printed by
+// some ultra_simple builds, e.g. "S theta: 12.50 Dist: 400.00 Q: 47".
+//
+// Parameters:
+//
+// measure: The Measure instance to initialize.
+// measureStr: String representation of the measurement.
+// parserConfig: Layout of the measure string.
+// isUpsideDown: Indicates if the RPLiDAR is upside down.
+// angleAdjustment: Angle adjustment to apply to the angle.
+//
+// Returns:
+//
+// An error if the string is not a valid measure.
+func parseFlexibleMeasure(
+	measure *Measure,
+	measureStr string,
+	parserConfig ParserConfig,
+	isUpsideDown bool,
+	angleAdjustment float64,
+) error {
+	// Trim and split, removing the labels printed by some ultra_simple builds
+	fields := stripMeasureLabels(parserConfig.splitFields(measureStr))
+
+	// Check if it has sync bit
+	fields, hasSyncBit := stripSyncBit(fields)
 
 	// Check number of fields
-	if len(fields) != 3 {
-		return nil, fmt.Errorf("expected 3 fields, got %d", len(fields))
+	if fieldCount := parserConfig.fieldCount(); len(fields) != fieldCount {
+		return fmt.Errorf("%w: expected %d, got %d", ErrMeasureFieldCount, fieldCount, len(fields))
 	}
 
 	// Parse fields
 	var angle float64
 	if err := gostringsconvert.ToFloat64(
-		fields[AngleIndex],
+		fields[parserConfig.AngleIndex],
 		&angle,
 	); err != nil {
-		return nil, fmt.Errorf("failed to parse angle: %w", err)
+		return fmt.Errorf("%w: %w", ErrParseAngle, err)
 	}
 
 	var distance float64
 	if err := gostringsconvert.ToFloat64(
-		fields[DistanceIndex],
+		fields[parserConfig.DistanceIndex],
 		&distance,
 	); err != nil {
-		return nil, fmt.Errorf("failed to parse distance: %w", err)
+		return fmt.Errorf("%w: %w", ErrParseDistance, err)
 	}
 
 	var quality int
 	if err := gostringsconvert.ToInt(
-		fields[QualityIndex],
+		fields[parserConfig.QualityIndex],
 		&quality,
 	); err != nil {
-		return nil, fmt.Errorf("failed to parse quality: %w", err)
+		return fmt.Errorf("%w: %w", ErrParseQuality, err)
 	}
 
-	// Create the Measure instance
-	return NewMeasure(
+	// Initialize the Measure instance
+	return initMeasure(
+		measure,
 		angle,
 		distance,
 		quality,
@@ -204,25 +699,166 @@ func NewMeasureFromString(
 	)
 }
 
-// GetAngle returns the angle of the measurement.
+// parsePlainMeasure parses a measure string with the plain whitespace-separated format, e.g. "S 12.50 400.00 47", without
+// allocating the fields. The sync bit marker can be a standalone token, or attached to the first or the last field.
+//
+// Parameters:
+//
+// measure: The Measure instance to initialize.
+// measureStr: String representation of the measurement.
+// parserConfig: Layout of the measure string.
+// isUpsideDown: Indicates if the RPLiDAR is upside down.
+// angleAdjustment: Angle adjustment to apply to the angle.
 //
 // Returns:
 //
-// The angle of the measurement in degrees.
-func (m *Measure) GetAngle() float64 {
-	return m.angle
+// True if the Measure instance was initialized, or false if the string doesn't have the plain format or is invalid.
+func parsePlainMeasure(
+	measure *Measure,
+	measureStr string,
+	parserConfig ParserConfig,
+	isUpsideDown bool,
+	angleAdjustment float64,
+) bool {
+	// Check if the layout can be parsed without allocating the fields
+	var fields [3]string
+	fieldCount := parserConfig.fieldCount()
+	if parserConfig.Separator != "" || fieldCount > len(fields) {
+		return false
+	}
+
+	// Split the fields on whitespace
+	count := 0
+	hasSyncBit := false
+	for index := 0; index < len(measureStr); {
+		// Skip the whitespace before the field
+		if isASCIISpace(measureStr[index]) {
+			index++
+			continue
+		}
+
+		start := index
+		for index < len(measureStr) && !isASCIISpace(measureStr[index]) {
+			index++
+		}
+		field := measureStr[start:index]
+
+		// Skip the standalone sync bit token
+		if field == SyncBitCharacter {
+			hasSyncBit = true
+			continue
+		}
+
+		// Check if there are more fields than expected
+		if count == fieldCount {
+			return false
+		}
+		fields[count] = field
+		count++
+	}
+	if count != fieldCount {
+		return false
+	}
+
+	// Check if the sync bit is attached to the first or the last field
+	if strings.HasPrefix(fields[0], SyncBitCharacter) {
+		fields[0] = fields[0][len(SyncBitCharacter):]
+		hasSyncBit = true
+	} else if strings.HasSuffix(fields[count-1], SyncBitCharacter) {
+		fields[count-1] = fields[count-1][:len(fields[count-1])-len(SyncBitCharacter)]
+		hasSyncBit = true
+	}
+
+	// Parse fields
+	angle, err := strconv.ParseFloat(fields[parserConfig.AngleIndex], 64)
+	if err != nil {
+		return false
+	}
+	distance, err := strconv.ParseFloat(fields[parserConfig.DistanceIndex], 64)
+	if err != nil {
+		return false
+	}
+	quality, err := strconv.Atoi(fields[parserConfig.QualityIndex])
+	if err != nil {
+		return false
+	}
+
+	// Initialize the Measure instance
+	return initMeasure(
+		measure,
+		angle,
+		distance,
+		quality,
+		hasSyncBit,
+		isUpsideDown,
+		angleAdjustment,
+	) == nil
 }
 
-// GetDistance returns the distance of the measurement.
+// isASCIISpace checks if the given byte is an ASCII whitespace character.
+//
+// Parameters:
+//
+// b: The byte to check.
 //
 // Returns:
 //
-// The distance of the measurement in millimeters.
-func (m *Measure) GetDistance() float64 {
-	return m.distance
+// True if the byte is an ASCII whitespace character, false otherwise.
+func isASCIISpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
 }
 
-// GetQuality returns the quality of the measurement.
+// GetAngle returns the angle of the measurement.
+//
+// Returns:
+//
+// The angle of the measurement in degrees.
+func (m *Measure) GetAngle() float64 {
+	return m.angle
+}
+
+// GetRawAngle returns the angle reported by the RPLiDAR, before applying the upside down and the angle adjustment
+// transforms.
+//
+// Returns:
+//
+// The raw angle of the measurement in degrees.
+func (m *Measure) GetRawAngle() float64 {
+	return m.rawAngle
+}
+
+// GetDistance returns the distance of the measurement.
+//
+// Returns:
+//
+// The distance of the measurement in millimeters.
+func (m *Measure) GetDistance() float64 {
+	return m.distance
+}
+
+// GetDistanceMeters returns the distance of the measurement in meters.
+//
+// Returns:
+//
+// The distance of the measurement in meters.
+func (m *Measure) GetDistanceMeters() float64 {
+	return DistanceUnitMeters.FromMillimeters(m.distance)
+}
+
+// GetDistanceIn returns the distance of the measurement in the given unit.
+//
+// Parameters:
+//
+// unit: The unit to return the distance in.
+//
+// Returns:
+//
+// The distance of the measurement in the given unit, or in millimeters if the unit is not valid.
+func (m *Measure) GetDistanceIn(unit DistanceUnit) float64 {
+	return unit.FromMillimeters(m.distance)
+}
+
+// GetQuality returns the quality of the measurement.
 //
 // Returns:
 //
@@ -231,6 +867,39 @@ func (m *Measure) GetQuality() int {
 	return m.quality
 }
 
+// GetNormalizedQuality returns the quality of the measurement relative to the quality scale of the RPLiDAR model, so
+// the same threshold works across models.
+//
+// Returns:
+//
+// The quality of the measurement within [0, 1], using DefaultQualityScale if the measure wasn't parsed by a handler.
+func (m *Measure) GetNormalizedQuality() float64 {
+	qualityScale := m.qualityScale
+	if qualityScale <= 0 {
+		qualityScale = DefaultQualityScale
+	}
+	return min(max(float64(m.quality)/float64(qualityScale), 0), 1)
+}
+
+// GetTimestamp returns the host time at which the measurement was received, since ultra_simple doesn't print one.
+//
+// Returns:
+//
+// The timestamp of the measurement.
+func (m *Measure) GetTimestamp() time.Time {
+	return m.timestamp
+}
+
+// IsInterpolated checks if the measure was synthesized by interpolating its neighbors instead of being returned by the
+// RPLiDAR.
+//
+// Returns:
+//
+// True if the measure is interpolated, false otherwise.
+func (m *Measure) IsInterpolated() bool {
+	return m.isInterpolated
+}
+
 // String returns the string representation of the Measure.
 //
 // Returns:
@@ -247,6 +916,133 @@ func (m *Measure) String() string {
 	)
 }
 
+// clone creates a copy of the measure.
+//
+// Returns:
+//
+// A pointer to a copy of the measure.
+func (m *Measure) clone() *Measure {
+	measureCopy := *m
+	return &measureCopy
+}
+
+// ToCartesian converts the measure from polar to Cartesian coordinates.
+//
+// The angle zero axis points north (+Y) and the angles grow clockwise, so east is +X, matching the CardinalDirection
+// angles. The upside-down and angle adjustment corrections are already applied to the measure angle.
+//
+// Returns:
+//
+// The x and y coordinates of the measure in millimeters.
+func (m *Measure) ToCartesian() (x, y float64) {
+	radians := m.angle * math.Pi / 180.0
+	return m.distance * math.Sin(radians), m.distance * math.Cos(radians)
+}
+
+// Equal checks if the measure has the same angle, distance and quality as another one.
+//
+// Parameters:
+//
+// other: The other measure.
+//
+// Returns:
+//
+// True if both measures are nil or have the same angle, distance and quality, false otherwise.
+func (m *Measure) Equal(other *Measure) bool {
+	return m.EqualWithTolerance(other, 0)
+}
+
+// EqualWithTolerance checks if the measure has the same quality as another one, and an angle and a distance that
+// differ by at most the tolerance. The angles are compared across the 0/360 seam.
+//
+// Parameters:
+//
+// other: The other measure.
+// tolerance: The maximum difference of the angles in degrees and of the distances in millimeters.
+//
+// Returns:
+//
+// True if both measures are nil or are equal within the tolerance, false otherwise.
+func (m *Measure) EqualWithTolerance(other *Measure, tolerance float64) bool {
+	// Check if any of the measures is nil
+	if m == nil || other == nil {
+		return m == other
+	}
+
+	// Compare the angles by their shortest difference
+	angleDiff := math.Abs(m.angle - other.angle)
+	angleDiff = min(angleDiff, 360-angleDiff)
+	return angleDiff <= tolerance &&
+		math.Abs(m.distance-other.distance) <= tolerance &&
+		m.quality == other.quality
+}
+
+// CompareByAngle compares two measures by their angle, e.g. to sort a slice of measures with slices.SortFunc, or with
+// sort.Slice by checking if the result is negative.
+//
+// Parameters:
+//
+// a: The first measure.
+// b: The second measure.
+//
+// Returns:
+//
+// A negative number if a has a lower angle than b, a positive number if it's higher, and zero if they're equal.
+func CompareByAngle(a *Measure, b *Measure) int {
+	return cmp.Compare(a.GetAngle(), b.GetAngle())
+}
+
+// DistanceTo calculates the straight-line distance between the measure and another one, e.g. to estimate the size of an
+// object from the first and last measures of a cluster.
+//
+// Parameters:
+//
+// other: The other measure.
+//
+// Returns:
+//
+// The Euclidean distance between both measures in millimeters.
+func (m *Measure) DistanceTo(other *Measure) float64 {
+	x, y := m.ToCartesian()
+	otherX, otherY := other.ToCartesian()
+	return math.Hypot(x-otherX, y-otherY)
+}
+
+// MarshalJSON returns the JSON representation of the Measure.
+//
+// Returns:
+//
+// The JSON encoded measure, or an error if it couldn't be encoded.
+func (m *Measure) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		measureJSON{
+			Angle:        m.angle,
+			Distance:     m.distance,
+			Quality:      m.quality,
+			SyncBit:      m.hasSyncBit,
+			Interpolated: m.isInterpolated,
+		},
+	)
+}
+
+// Transform applies the rigid transform of the mount pose to a point in the RPLiDAR frame.
+//
+// Parameters:
+//
+// point: The (x, y) point in millimeters in the RPLiDAR frame.
+//
+// Returns:
+//
+// The (x, y) point in millimeters in the robot frame.
+func (p MountPose) Transform(point [2]float64) [2]float64 {
+	yaw := p.Yaw * math.Pi / 180
+	sin, cos := math.Sin(yaw), math.Cos(yaw)
+	return [2]float64{
+		point[0]*cos + point[1]*sin + p.OffsetX,
+		-point[0]*sin + point[1]*cos + p.OffsetY,
+	}
+}
+
 // IsRotationCompleted determines if a full rotation has been completed
 //
 // Returns:
@@ -270,6 +1066,7 @@ func (m *Measure) IsRotationCompleted() bool {
 // maxDistanceLimit: Maximum distance limit for valid measurements.
 // measuresChSize: Size of the channel to send measures.
 // debug: If true, enables debug logging.
+// options: Optional settings for the handler.
 //
 // Returns:
 //
@@ -285,41 +1082,223 @@ func NewDefaultHandler(
 	maxDistanceLimit float64,
 	measuresChSize int,
 	debug bool,
+	options ...Option,
 ) (*DefaultHandler, error) {
-	// Check if the logger is nil
-	if logger == nil {
-		return nil, goconcurrentlogger.ErrNilLogger
-	}
-
 	// Check if the ultra simple path is empty
 	if strings.TrimSpace(ultraSimplePath) == "" {
 		return nil, ErrEmptyUltraSimplePath
 	}
 
+	// Check if the port is empty
+	if strings.TrimSpace(port) == "" {
+		return nil, ErrEmptyPort
+	}
+
+	// Check if the baud rate is valid
+	if baudRate <= 0 {
+		return nil, ErrInvalidBaudRate
+	}
+
+	// Create the handler
+	handler, err := newHandler(
+		baudRate,
+		port,
+		isUpsideDown,
+		angleAdjustment,
+		minimumQuality,
+		logger,
+		ultraSimplePath,
+		maxDistanceLimit,
+		measuresChSize,
+		debug,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the port device exists
+	if !handler.skipPortCheck {
+		if err = checkPortExists(port); err != nil {
+			return nil, err
+		}
+	}
+	return handler, nil
+}
+
+// newHandler creates a new DefaultHandler instance validating the settings shared by all the measure sources.
+//
+// Parameters:
+//
+// baudRate: Baud rate for the serial communication.
+// port: SerialCommunication port for the RPLiDAR.
+// isUpsideDown: If true, the RPLiDAR is upside down, and angles will be adjusted accordingly.
+// angleAdjustment: Optional angle adjustment to apply to the angles.
+// minimumQuality: Minimum quality for a valid measurement.
+// logger: Logger instance for logging messages.
+// ultraSimplePath: Path to the ultra_simple executable.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// measuresChSize: Size of the channel to send measures.
+// debug: If true, enables debug logging.
+// options: Optional settings for the handler.
+//
+// Returns:
+//
+// A pointer to a DefaultHandler instance or an error if any parameter is invalid.
+func newHandler(
+	baudRate int,
+	port string,
+	isUpsideDown bool,
+	angleAdjustment float64,
+	minimumQuality int,
+	logger goconcurrentlogger.Logger,
+	ultraSimplePath string,
+	maxDistanceLimit float64,
+	measuresChSize int,
+	debug bool,
+	options ...Option,
+) (*DefaultHandler, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, goconcurrentlogger.ErrNilLogger
+	}
+
 	// Check if the max distance limit is valid
 	if maxDistanceLimit <= 0 {
 		return nil, ErrInvalidMaxDistanceLimit
 	}
 
+	// Create a new DefaultHandler instance
+	handler := &DefaultHandler{
+		logger:                    logger,
+		baudRate:                  baudRate,
+		port:                      port,
+		isUpsideDown:              isUpsideDown,
+		angleAdjustment:           angleAdjustment,
+		minimumQuality:            minimumQuality,
+		ultraSimplePath:           ultraSimplePath,
+		maxDistanceLimit:          maxDistanceLimit,
+		measuresChSize:            measuresChSize,
+		debug:                     debug,
+		readyCh:                   make(chan struct{}),
+		subscribers:               make(map[chan *Measure]struct{}),
+		subscriberBufferSize:      DefaultSubscriberBufferSize,
+		subscriberOverflowPolicy:  DefaultSubscriberOverflowPolicy,
+		rotationEventsCh:          make(chan RotationCompleted, RotationEventsChannelSize),
+		parseErrorsCh:             make(chan error, ParseErrorsChannelSize),
+		ignoreFirstStdoutMessages: IgnoreFirstStdoutMessages,
+		closeTimeout:              CloseTimeout,
+		bucketsPerDegree:          DefaultBucketsPerDegree,
+		initialBufferSize:         InitialSizeBuffer,
+		maxBufferSize:             MaxSizeBuffer,
+		maxLineLength:             DefaultMaxLineLength,
+		parserConfig:              DefaultParserConfig,
+		smoothingAlpha:            NoSmoothingAlpha,
+		scanHistorySize:           DefaultScanHistorySize,
+		qualityScale:              DefaultQualityScale,
+		healthMaxDataAge:          DefaultHealthMaxDataAge,
+		healthMinScanFrequency:    DefaultHealthMinScanFrequency,
+		healthMinCoverage:         DefaultHealthMinCoverage,
+	}
+
+	// Apply the optional settings
+	for _, option := range options {
+		if option != nil {
+			option(handler)
+		}
+	}
+
 	// Check if the measures channel size is valid
-	if measuresChSize <= 0 {
+	if handler.measuresChSize <= 0 {
 		return nil, ErrInvalidMeasuresChannelSize
 	}
 
-	// Create a new DefaultHandler instance
-	return &DefaultHandler{
-		logger:           logger,
-		baudRate:         baudRate,
-		port:             port,
-		isUpsideDown:     isUpsideDown,
-		angleAdjustment:  angleAdjustment,
-		minimumQuality:   minimumQuality,
-		ultraSimplePath:  ultraSimplePath,
-		maxDistanceLimit: maxDistanceLimit,
-		measuresChSize:   measuresChSize,
-		debug:            debug,
-		readyCh:          make(chan struct{}),
-	}, nil
+	// Check if the subscriber settings are valid
+	if handler.subscriberBufferSize <= 0 {
+		return nil, ErrInvalidSubscriberBufferSize
+	}
+	if _, ok := SubscriberOverflowPolicyNames[handler.subscriberOverflowPolicy]; !ok {
+		return nil, ErrInvalidSubscriberOverflowPolicy
+	}
+
+	// Check if the number of initial stdout messages to ignore is valid
+	if handler.ignoreFirstStdoutMessages < 0 {
+		return nil, ErrInvalidIgnoreFirstStdoutMessages
+	}
+
+	// Check if the angular resolution is valid
+	if handler.bucketsPerDegree < 1 || handler.bucketsPerDegree > MaxBucketsPerDegree {
+		return nil, ErrInvalidBucketsPerDegree
+	}
+
+	// Check if the measure TTL is valid
+	if handler.measureTTL < 0 {
+		return nil, ErrInvalidMeasureTTL
+	}
+
+	// Check if the buffer sizes are valid
+	if handler.initialBufferSize <= 0 || handler.initialBufferSize > handler.maxBufferSize {
+		return nil, ErrInvalidBufferSizes
+	}
+
+	// Check if the max line length is valid
+	if handler.maxLineLength < 0 || handler.maxLineLength >= handler.maxBufferSize {
+		return nil, ErrInvalidMaxLineLength
+	}
+
+	// Check if the measure pool is compatible with the settings that keep references to the stored measures
+	if handler.measurePool != nil && (handler.accumulateMeasures || handler.bucketsPerDegree > 1) {
+		return nil, ErrMeasurePoolUnsupported
+	}
+
+	// Check if the number of parser workers is valid
+	if handler.parserWorkers < 0 {
+		return nil, ErrInvalidParserWorkers
+	}
+
+	// Check if the quality scale is valid
+	if handler.qualityScale <= 0 {
+		return nil, ErrInvalidQualityScale
+	}
+
+	// Check if the scan history size is valid
+	if handler.scanHistorySize < 0 {
+		return nil, ErrInvalidScanHistorySize
+	}
+
+	// Check if the health thresholds are valid
+	if handler.healthMaxDataAge <= 0 || handler.healthMinScanFrequency < 0 || handler.healthMinCoverage < 0 ||
+		handler.healthMinCoverage > 1 {
+		return nil, ErrInvalidHealthThresholds
+	}
+
+	// Check if the parser config is valid
+	if err := handler.parserConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Check if the data timeout is valid
+	if handler.dataTimeout < 0 {
+		return nil, ErrInvalidDataTimeout
+	}
+
+	// Check if the min distance limit is valid
+	if handler.minDistanceLimit < 0 || handler.minDistanceLimit >= handler.maxDistanceLimit {
+		return nil, ErrInvalidMinDistanceLimit
+	}
+
+	// Check if the close timeout is valid
+	if handler.closeTimeout <= 0 {
+		return nil, ErrInvalidCloseTimeout
+	}
+
+	// Check if the extra arguments duplicate the managed ones
+	for _, arg := range handler.extraArgs {
+		if arg == UltraSimpleChannelArgument || arg == UltraSimpleSerialArgument {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateUltraSimpleArgument, arg)
+		}
+	}
+	return handler, nil
 }
 
 // NewSlamtecC1Handler creates a new DefaultHandler instance configured for the Slamtec RPLiDAR C1 model.
@@ -335,6 +1314,7 @@ func NewDefaultHandler(
 // maxDistanceLimit: Maximum distance limit for valid measurements.
 // measuresChSize: Size of the channel to send measures.
 // debug: If true, enables debug logging.
+// options: Optional settings for the handler.
 //
 // Returns:
 //
@@ -349,6 +1329,7 @@ func NewSlamtecC1Handler(
 	maxDistanceLimit float64,
 	measuresChSize int,
 	debug bool,
+	options ...Option,
 ) (*DefaultHandler, error) {
 	return NewDefaultHandler(
 		SlamtecC1BaudRate,
@@ -361,35 +1342,256 @@ func NewSlamtecC1Handler(
 		maxDistanceLimit,
 		measuresChSize,
 		debug,
+		append([]Option{WithQualityScale(SlamtecC1QualityScale)}, options...)...,
 	)
 }
 
-// IsRunning checks if the handler is currently running.
+// NewSlamtecA1Handler creates a new DefaultHandler instance configured for the Slamtec RPLiDAR A1 model.
+//
+// Parameters:
+//
+// port: SerialCommunication port for the RPLiDAR A1.
+// isUpsideDown: If true, the RPLiDAR is upside down, and angles will be adjusted accordingly.
+// angleAdjustment: Optional angle adjustment to apply to the angles.
+// minimumQuality: Minimum quality for a valid measurement.
+// logger: Logger instance for logging messages.
+// ultraSimplePath: Path to the ultra_simple executable.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// measuresChSize: Size of the channel to send measures.
+// debug: If true, enables debug logging.
+// options: Optional settings for the handler.
 //
 // Returns:
 //
-// True if the handler is running, false otherwise.
-func (h *DefaultHandler) IsRunning() bool {
-	return h.isRunning.Load()
+// A pointer to a DefaultHandler instance or an error if any parameter is invalid.
+func NewSlamtecA1Handler(
+	port string,
+	isUpsideDown bool,
+	angleAdjustment float64,
+	minimumQuality int,
+	logger goconcurrentlogger.Logger,
+	ultraSimplePath string,
+	maxDistanceLimit float64,
+	measuresChSize int,
+	debug bool,
+	options ...Option,
+) (*DefaultHandler, error) {
+	return NewDefaultHandler(
+		SlamtecA1BaudRate,
+		port,
+		isUpsideDown,
+		angleAdjustment,
+		minimumQuality,
+		logger,
+		ultraSimplePath,
+		maxDistanceLimit,
+		measuresChSize,
+		debug,
+		append([]Option{WithQualityScale(SlamtecA1QualityScale)}, options...)...,
+	)
 }
 
-// runToWrap is the internal function to read incoming measures from the RPLiDAR and process them.
+// NewSlamtecA2Handler creates a new DefaultHandler instance configured for the Slamtec RPLiDAR A2 model.
 //
 // Parameters:
 //
-// ctx: Context for managing cancellation and timeouts.
-// cancelFn: Function to cancel the context in case of an error.
-//
+// port: SerialCommunication port for the RPLiDAR A2.
+// isUpsideDown: If true, the RPLiDAR is upside down, and angles will be adjusted accordingly.
+// angleAdjustment: Optional angle adjustment to apply to the angles.
+// minimumQuality: Minimum quality for a valid measurement.
+// logger: Logger instance for logging messages.
+// ultraSimplePath: Path to the ultra_simple executable.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// measuresChSize: Size of the channel to send measures.
+// debug: If true, enables debug logging.
+// options: Optional settings for the handler.
+//
 // Returns:
 //
-// An error if any issue occurs during reading or processing measures.
-func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelFunc) error {
+// A pointer to a DefaultHandler instance or an error if any parameter is invalid.
+func NewSlamtecA2Handler(
+	port string,
+	isUpsideDown bool,
+	angleAdjustment float64,
+	minimumQuality int,
+	logger goconcurrentlogger.Logger,
+	ultraSimplePath string,
+	maxDistanceLimit float64,
+	measuresChSize int,
+	debug bool,
+	options ...Option,
+) (*DefaultHandler, error) {
+	return NewDefaultHandler(
+		SlamtecA2BaudRate,
+		port,
+		isUpsideDown,
+		angleAdjustment,
+		minimumQuality,
+		logger,
+		ultraSimplePath,
+		maxDistanceLimit,
+		measuresChSize,
+		debug,
+		append([]Option{WithQualityScale(SlamtecA2QualityScale)}, options...)...,
+	)
+}
+
+// IsRunning checks if the handler is currently running.
+//
+// Returns:
+//
+// True if the handler is running, false otherwise.
+func (h *DefaultHandler) IsRunning() bool {
+	return h.isRunning.Load()
+}
+
+// GetPort returns the serial port of the RPLiDAR device.
+//
+// Returns:
+//
+// The serial port.
+func (h *DefaultHandler) GetPort() string {
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+	return h.port
+}
+
+// GetBaudRate returns the baud rate of the RPLiDAR device.
+//
+// Returns:
+//
+// The baud rate.
+func (h *DefaultHandler) GetBaudRate() int {
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+	return h.baudRate
+}
+
+// Reconfigure changes the serial port and the baud rate of the RPLiDAR device, e.g. to cycle through several devices
+// without creating a new handler. It must be called while the handler is not running.
+//
+// Parameters:
+//
+// port: SerialCommunication port for the RPLiDAR.
+// baudRate: Baud rate for the serial communication.
+//
+// Returns:
+//
+// An error if the handler doesn't execute ultra_simple, if the handler is running, or if any parameter is invalid.
+func (h *DefaultHandler) Reconfigure(port string, baudRate int) error {
+	// Check if the handler executes ultra_simple
+	if strings.TrimSpace(h.ultraSimplePath) == "" {
+		return ErrEmptyUltraSimplePath
+	}
+
+	// Check if the port is empty
+	if strings.TrimSpace(port) == "" {
+		return ErrEmptyPort
+	}
+
+	// Check if the baud rate is valid
+	if baudRate <= 0 {
+		return ErrInvalidBaudRate
+	}
+
+	// Check if the port device exists
+	if !h.skipPortCheck {
+		if err := checkPortExists(port); err != nil {
+			return err
+		}
+	}
+
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+
+	// Check if it's running, since the running process keeps the previous settings
+	if h.IsRunning() {
+		return ErrHandlerAlreadyRunning
+	}
+	h.port = port
+	h.baudRate = baudRate
+	return nil
+}
+
+// IsUpsideDown checks if the RPLiDAR device is mounted upside down.
+//
+// Returns:
+//
+// True if the RPLiDAR device is upside down, false otherwise.
+func (h *DefaultHandler) IsUpsideDown() bool {
+	return h.isUpsideDown
+}
+
+// GetAngleAdjustment returns the angle adjustment applied to the measures. A whole number of degrees is applied as an
+// exact rotation of the degrees, see NewMeasure.
+//
+// Returns:
+//
+// The angle adjustment in degrees.
+func (h *DefaultHandler) GetAngleAdjustment() float64 {
+	return h.angleAdjustment
+}
+
+// GetMaxDistanceLimit returns the maximum distance limit for valid measurements.
+//
+// Returns:
+//
+// The maximum distance limit.
+func (h *DefaultHandler) GetMaxDistanceLimit() float64 {
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+	return h.maxDistanceLimit
+}
+
+// resetRunState resets the measures and the stdout parsing state before reading from a new measure source.
+func (h *DefaultHandler) resetRunState() {
 	// Initialize the measures slice
-	h.measures = [360]*Measure{}
+	h.ClearMeasures()
 
 	// Reset the stdout lines read counter
 	h.stdoutLinesRead = 0
 
+	// Reset the measurement data detection, so the banner lines are skipped again
+	h.isReceivingMeasures = false
+
+	// Reset the rotation timestamps
+	h.resetRotationTimestamps()
+
+	// Reset the scan history
+	h.resetScanHistory()
+
+	// Reset the device info, so it's parsed again from the banner lines
+	h.resetDeviceInfo()
+
+	// Reset the recent stderr lines
+	h.stderrMutex.Lock()
+	h.recentStderr = nil
+	h.stderrMutex.Unlock()
+
+	// Reset the stats
+	h.rotationCount.Store(0)
+	h.linesRead.Store(0)
+	h.measuresParsed.Store(0)
+	h.parseErrors.Store(0)
+	h.outOfRangeDropped.Store(0)
+	h.qualityDropped.Store(0)
+	h.maskedDropped.Store(0)
+}
+
+// runToWrap is the internal function to read incoming measures from the RPLiDAR and process them.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+// cancelFn: Function to cancel the context in case of an error.
+//
+// Returns:
+//
+// An error if any issue occurs during reading or processing measures.
+func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelFunc) error {
+	// Reset the state of the previous run
+	h.resetRunState()
+
 	// Log the initialization of reading measures
 	h.handlerLoggerProducer.Info(HandlerInitializedMessage)
 
@@ -408,6 +1610,7 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 		h.port,
 		strconv.Itoa(h.baudRate),
 	}
+	args = append(args, h.extraArgs...)
 
 	// Execute the command with a context
 	cmd := exec.CommandContext(ctx, h.ultraSimplePath, args...)
@@ -426,6 +1629,12 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 	if err = cmd.Start(); err != nil {
 		return fmt.Errorf("start command error: %w", err)
 	}
+	h.signalStarted()
+
+	// Tee the stdout lines to the capture writer
+	if stopCapture := h.startCapture(); stopCapture != nil {
+		defer stopCapture()
+	}
 
 	// Create an error group to wait for all goroutines to finish
 	g := &errgroup.Group{}
@@ -436,12 +1645,20 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 			ctx,
 			cancelFn,
 			func(ctx context.Context) error {
-				return h.scanLines(
+				lineHandler, stopParserPool := h.newStdoutLineHandler()
+				err := h.scanLines(
 					ctx,
 					StdoutTag,
 					stdout,
-					h.handleStdoutLine,
+					lineHandler,
+					h.handlerLoggerProducer,
 				)
+
+				// Wait until the queued lines are handled
+				if stopErr := stopParserPool(); stopErr != nil && err == nil {
+					return stopErr
+				}
+				return err
 			},
 			h.handlerLoggerProducer,
 		),
@@ -458,6 +1675,7 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 					StderrTag,
 					stderr,
 					h.handleStderrLine,
+					h.handlerLoggerProducer,
 				)
 			},
 			h.handlerLoggerProducer,
@@ -475,6 +1693,9 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 		return err
 	}
 
+	// Check if the stop was requested, otherwise the process exited by itself
+	stopRequested := ctx.Err() != nil
+
 	// Log the process exit
 	h.handlerLoggerProducer.Info("RPLiDAR process exiting...")
 
@@ -482,42 +1703,186 @@ func (h *DefaultHandler) runToWrap(ctx context.Context, cancelFn context.CancelF
 	_ = stdout.Close()
 	_ = stderr.Close()
 
-	// Signal the process to stop (SIGINT)
-	_ = cmd.Process.Signal(os.Interrupt)
+	// Stop the process
+	waitErr := h.stopProcess(cmd, h.handlerLoggerProducer)
+
+	// Get the exit code of the process
+	exitCode := -1
+	var exitErr *exec.ExitError
+	if waitErr == nil {
+		exitCode = 0
+	} else if errors.As(waitErr, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+
+	// Send the process exit event
+	if eventSink := h.getEventSink(); eventSink != nil {
+		if stopRequested {
+			eventSink.OnProcessExit(exitCode, nil)
+		} else {
+			eventSink.OnProcessExit(exitCode, waitErr)
+		}
+	}
+	if stopRequested {
+		return nil
+	}
+
+	// Check if the process exited cleanly, which is still unexpected since it's meant to run until stopped
+	if waitErr == nil {
+		return fmt.Errorf(
+			"%w with exit code %d",
+			ErrProcessExited,
+			exitCode,
+		)
+	}
+
+	// Include the recent stderr lines to make the failure cause visible
+	recentStderr := h.GetRecentStderr()
+	if len(recentStderr) > 0 {
+		return fmt.Errorf(
+			"%w with exit code %d: %w (stderr: %s)",
+			ErrProcessExited,
+			exitCode,
+			waitErr,
+			strings.Join(recentStderr, "; "),
+		)
+	}
+	return fmt.Errorf(
+		"%w with exit code %d: %w",
+		ErrProcessExited,
+		exitCode,
+		waitErr,
+	)
+}
+
+// Run reads incoming measures from the RPLiDAR and processes them.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+// cancelFn: Function to cancel the context in case of an error.
+//
+// Returns:
+//
+// An error if any issue occurs during reading or processing measures. The cause of the stop can be told apart with
+// errors.Is: context.Canceled (or context.DeadlineExceeded) if the context is done or Stop is called, ErrDataTimeout if
+// no measure is parsed within the data timeout, and ErrProcessExited if ultra_simple exits by itself.
+func (h *DefaultHandler) Run(ctx context.Context, cancelFn context.CancelFunc) error {
+	return h.run(ctx, cancelFn, nil)
+}
+
+// Start runs the handler in the background, and returns once the measure source has started, e.g. the ultra_simple
+// process has been spawned, so the startup errors are returned synchronously. The runtime errors are returned by Wait.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+//
+// Returns:
+//
+// An error if the handler is already running or the measure source couldn't be started.
+func (h *DefaultHandler) Start(ctx context.Context) error {
+	ctx, cancelFn := context.WithCancel(ctx)
 
-	// Wait for the process to exit or timeout
-	done := make(chan struct{})
+	// Run the handler in the background
+	startedCh := make(chan struct{})
+	var startedOnce sync.Once
+	errCh := make(chan error, 1)
 	go func() {
-		cmd.Wait()
-		close(done)
+		errCh <- h.run(
+			ctx,
+			cancelFn,
+			func() {
+				startedOnce.Do(func() { close(startedCh) })
+			},
+		)
 	}()
 
+	// Wait until the measure source has started or the run has finished
 	select {
-	case <-done:
-		// Process exited gracefully
-		h.handlerLoggerProducer.Info("RPLiDAR process exited gracefully")
-	case <-time.After(CloseTimeout):
-		// Timeout, force kill
-		_ = cmd.Process.Kill()
-		h.handlerLoggerProducer.Warning("RPLiDAR process killed after timeout")
+	case <-startedCh:
+	case err := <-errCh:
+		cancelFn()
+		if err == nil {
+			return ErrRunFinishedBeforeStart
+		}
+		return err
 	}
+
+	// Keep the result of the run for Wait
+	runWaitCh := make(chan struct{})
+	h.handlerMutex.Lock()
+	h.runWaitCh = runWaitCh
+	h.runErr = nil
+	h.handlerMutex.Unlock()
+	go func() {
+		err := <-errCh
+		cancelFn()
+
+		h.handlerMutex.Lock()
+		h.runErr = err
+		h.handlerMutex.Unlock()
+		close(runWaitCh)
+	}()
 	return nil
+}
+
+// Wait waits until the run launched by Start finishes.
+//
+// Returns:
+//
+// The error returned by the run, or ErrHandlerIsNotRunning if it wasn't launched by Start.
+func (h *DefaultHandler) Wait() error {
+	h.handlerMutex.Lock()
+	runWaitCh := h.runWaitCh
+	h.handlerMutex.Unlock()
 
+	// Check if the run was launched by Start
+	if runWaitCh == nil {
+		return ErrHandlerIsNotRunning
+	}
+	<-runWaitCh
+
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+	return h.runErr
 }
 
-// Run reads incoming measures from the RPLiDAR and processes them.
+// signalStarted notifies Start that the measure source has started.
+func (h *DefaultHandler) signalStarted() {
+	h.handlerMutex.Lock()
+	onStarted := h.onStarted
+	h.handlerMutex.Unlock()
+
+	if onStarted != nil {
+		onStarted()
+	}
+}
+
+// run is the internal function to read incoming measures from the RPLiDAR and process them.
 //
 // Parameters:
 //
 // ctx: Context for managing cancellation and timeouts.
 // cancelFn: Function to cancel the context in case of an error.
+// onStarted: Function called once the measure source has started, or nil.
 //
 // Returns:
 //
 // An error if any issue occurs during reading or processing measures.
-func (h *DefaultHandler) Run(ctx context.Context, cancelFn context.CancelFunc) error {
+func (h *DefaultHandler) run(
+	ctx context.Context,
+	cancelFn context.CancelFunc,
+	onStarted func(),
+) error {
 	h.handlerMutex.Lock()
 
+	// Check if it's closed
+	if h.isClosed.Load() {
+		h.handlerMutex.Unlock()
+		return ErrHandlerClosed
+	}
+
 	// Check if it's already running
 	if h.IsRunning() {
 		h.handlerMutex.Unlock()
@@ -534,9 +1899,21 @@ func (h *DefaultHandler) Run(ctx context.Context, cancelFn context.CancelFunc) e
 	// Reset measures
 	h.measures = [360]*Measure{}
 
+	// Reset the time of the last parsed measure before the data watchdog starts
+	h.lastMeasureAt.Store(0)
+
 	// Create the measures channel
 	h.measuresCh = make(chan *Measure, h.measuresChSize)
 
+	// Create the done channel to notify the subscribers when the handler stops
+	h.doneCh = make(chan struct{})
+
+	// Create the run context, so the handler can be stopped without the caller context
+	ctx, runCancelFn := context.WithCancel(ctx)
+	defer runCancelFn()
+	h.runCancelFn = runCancelFn
+	h.onStarted = onStarted
+
 	h.handlerMutex.Unlock()
 
 	// Create a logger producer
@@ -550,64 +1927,305 @@ func (h *DefaultHandler) Run(ctx context.Context, cancelFn context.CancelFunc) e
 	h.handlerLoggerProducer = handlerLoggerProducer
 	defer h.handlerLoggerProducer.Close()
 
-	return goconcurrentlogger.CancelContextAndLogOnError(
+	err = goconcurrentlogger.CancelContextAndLogOnError(
 		ctx,
 		cancelFn,
 		func(ctx context.Context) error {
-			return h.runToWrap(ctx, cancelFn)
+			// Stop the run if no measure is parsed within the data timeout
+			if h.dataTimeout > 0 {
+				watchdogCtx, watchdogCancelFn := context.WithCancelCause(ctx)
+				defer watchdogCancelFn(nil)
+				go h.watchData(watchdogCtx, watchdogCancelFn)
+
+				if err := h.runMeasureSource(watchdogCtx, cancelFn); err != nil {
+					return err
+				}
+
+				// Check if the run was stopped by the watchdog
+				if errors.Is(context.Cause(watchdogCtx), ErrDataTimeout) {
+					return ErrDataTimeout
+				}
+				return nil
+			}
+			return h.runMeasureSource(ctx, cancelFn)
 		},
 		h.handlerLoggerProducer,
 	)()
-}
-
-// close closes the handler and releases resources, but the context must be cancelled externally.
-func (h *DefaultHandler) close() {
-	h.handlerMutex.Lock()
-
-	// Check if the handler is already closed
-	if !h.IsRunning() {
-		h.handlerMutex.Unlock()
-		return
+	if err != nil {
+		return err
 	}
 
-	// Mark the handler as closed
-	h.isRunning.Store(false)
-
-	h.handlerMutex.Unlock()
-
-	// Reset has started sending state
-	h.hasStartedSending.Store(false)
-
-	// Close the measures channel
-	close(h.measuresCh)
-	h.measuresCh = nil
-
-	// Reset the ready channel
-	h.readyCh = make(chan struct{})
+	// Check if the run was stopped by the context, so the caller can tell it apart from a finished measure source
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if cause := context.Cause(ctx); !errors.Is(cause, ctxErr) {
+			return fmt.Errorf("%w: %w", ctxErr, cause)
+		}
+		return ctxErr
+	}
+	return nil
 }
 
-// StartSendingMeasures sets the handler to start sending measures through the measures channel.
+// runMeasureSource reads the measures from the measure source of the handler.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+// cancelFn: Function to cancel the context in case of an error.
 //
 // Returns:
 //
-// An error if the handler is not running.
-func (h *DefaultHandler) StartSendingMeasures() error {
-	h.handlerMutex.Lock()
-	defer h.handlerMutex.Unlock()
-	if !h.IsRunning() {
-		return ErrHandlerIsNotRunning
+// An error if any issue occurs during reading or processing measures.
+func (h *DefaultHandler) runMeasureSource(ctx context.Context, cancelFn context.CancelFunc) error {
+	// Check if the handler reads from a different measure source than ultra_simple
+	if h.runToWrapFn != nil {
+		return h.runToWrapFn(ctx, cancelFn)
 	}
-	h.hasStartedSending.Store(true)
-	return nil
+	return h.runToWrap(ctx, cancelFn)
 }
 
-// StopSendingMeasures sets the handler to stop sending measures through the measures channel.
+// watchData cancels the context with ErrDataTimeout if no measure is parsed within the data timeout.
 //
-// Returns:
+// Parameters:
 //
-// An error if the handler is not running.
-func (h *DefaultHandler) StopSendingMeasures() error {
-	h.handlerMutex.Lock()
+// ctx: Context for managing cancellation and timeouts.
+// cancelFn: Function to cancel the context with the cause.
+func (h *DefaultHandler) watchData(ctx context.Context, cancelFn context.CancelCauseFunc) {
+	// Count the data timeout from the start of the watchdog until the first measure is parsed
+	startedAt := time.Now().UnixNano()
+	lastMeasureAt := func() time.Time {
+		return time.Unix(0, max(startedAt, h.lastMeasureAt.Load()))
+	}
+
+	for {
+		// Wait until the data timeout elapses since the last parsed measure
+		deadline := lastMeasureAt().Add(h.dataTimeout)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(deadline)):
+		}
+
+		// Check if a measure was parsed while waiting
+		if time.Since(lastMeasureAt()) >= h.dataTimeout {
+			h.handlerLoggerProducer.Warning(
+				fmt.Sprintf(
+					"No measure received within %s, stopping the run",
+					h.dataTimeout,
+				),
+			)
+			cancelFn(ErrDataTimeout)
+			return
+		}
+	}
+}
+
+// Validate checks if the retry policy is valid.
+//
+// Returns:
+//
+// An error if any field of the retry policy is invalid.
+func (r RetryPolicy) Validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("%w: max attempts cannot be negative", ErrInvalidRetryPolicy)
+	}
+	if r.InitialBackoff <= 0 {
+		return fmt.Errorf("%w: initial backoff must be greater than zero", ErrInvalidRetryPolicy)
+	}
+	if r.MaxBackoff < r.InitialBackoff {
+		return fmt.Errorf("%w: max backoff must be greater than or equal to the initial backoff", ErrInvalidRetryPolicy)
+	}
+	if r.Multiplier < 1 {
+		return fmt.Errorf("%w: multiplier must be greater than or equal to 1", ErrInvalidRetryPolicy)
+	}
+	return nil
+}
+
+// RunWithRetry runs the handler and relaunches ultra_simple with exponential backoff each time it exits unexpectedly.
+//
+// Each attempt runs with its own child context, so a failed attempt doesn't cancel the given context. The measures are
+// cleared on each fresh start.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation, retries stop when it's cancelled.
+// stopFn: Function to cancel the context when the max number of attempts is reached.
+// policy: Retry policy with the backoff and the max number of attempts.
+//
+// Returns:
+//
+// An error if the retry policy is invalid, or if the max number of attempts is reached.
+func (h *DefaultHandler) RunWithRetry(
+	ctx context.Context,
+	stopFn context.CancelFunc,
+	policy RetryPolicy,
+) error {
+	// Check if the retry policy is valid
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	// Create a logger producer for the retries, since the handler logger producer only lives during each attempt
+	retryLoggerProducer, err := h.logger.NewProducer(
+		HandlerRetryLoggerProducerTag,
+		h.debug,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create retry logger producer: %w", err)
+	}
+	defer retryLoggerProducer.Close()
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		retryLoggerProducer.Info(
+			fmt.Sprintf(
+				"Starting RPLiDAR handler, attempt %d",
+				attempt,
+			),
+		)
+
+		// Run the handler with a child context
+		attemptCtx, attemptCancelFn := context.WithCancel(ctx)
+		err = h.Run(attemptCtx, attemptCancelFn)
+		attemptCancelFn()
+
+		// Check if the context was cancelled
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// Check if the handler was already running, retrying won't help
+		if errors.Is(err, ErrHandlerAlreadyRunning) {
+			return err
+		}
+
+		// Check if the max number of attempts has been reached
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			if stopFn != nil {
+				stopFn()
+			}
+			if err != nil {
+				return fmt.Errorf("%w (%d): %w", ErrMaxRetryAttemptsReached, attempt, err)
+			}
+			return fmt.Errorf("%w (%d)", ErrMaxRetryAttemptsReached, attempt)
+		}
+
+		retryLoggerProducer.Warning(
+			fmt.Sprintf(
+				"RPLiDAR process exited unexpectedly (%v), retrying in %s",
+				err,
+				backoff,
+			),
+		)
+
+		// Wait for the backoff or the context cancellation
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		// Increase the backoff
+		backoff = min(
+			time.Duration(float64(backoff)*policy.Multiplier),
+			policy.MaxBackoff,
+		)
+	}
+}
+
+// close closes the handler and releases resources, but the context must be cancelled externally.
+func (h *DefaultHandler) close() {
+	h.handlerMutex.Lock()
+
+	// Check if the handler is already closed
+	if !h.IsRunning() {
+		h.handlerMutex.Unlock()
+		return
+	}
+
+	// Mark the handler as closed
+	h.isRunning.Store(false)
+
+	h.handlerMutex.Unlock()
+
+	// Reset has started sending state
+	h.hasStartedSending.Store(false)
+
+	// Close the measures channel
+	close(h.measuresCh)
+	h.measuresCh = nil
+
+	// Notify the subscribers that the handler has stopped
+	close(h.doneCh)
+
+	// Reset the ready channel
+	h.readyCh = make(chan struct{})
+}
+
+// Close stops the handler if it's running, which releases the handler logger producer and the ultra_simple process,
+// and prevents it from running again. It's a no-op if the handler is already closed.
+//
+// Returns:
+//
+// An error if the handler couldn't be stopped.
+func (h *DefaultHandler) Close() error {
+	h.handlerMutex.Lock()
+	isClosed := h.isClosed.Swap(true)
+	h.handlerMutex.Unlock()
+
+	// Check if it was already closed
+	if isClosed {
+		return nil
+	}
+	return h.Stop()
+}
+
+// Stop stops the handler without cancelling the context passed to Run, and waits until the run finishes, including the
+// exit of the ultra_simple process. It's a no-op if the handler is not running.
+//
+// Returns:
+//
+// Always nil, since stopping a handler that is not running is a no-op.
+func (h *DefaultHandler) Stop() error {
+	h.handlerMutex.Lock()
+
+	// Check if it's running
+	if !h.IsRunning() {
+		h.handlerMutex.Unlock()
+		return nil
+	}
+	runCancelFn := h.runCancelFn
+	doneCh := h.doneCh
+
+	h.handlerMutex.Unlock()
+
+	// Stop the run and wait for it to finish
+	runCancelFn()
+	<-doneCh
+	return nil
+}
+
+// StartSendingMeasures sets the handler to start sending measures through the measures channel.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (h *DefaultHandler) StartSendingMeasures() error {
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+	if !h.IsRunning() {
+		return ErrHandlerIsNotRunning
+	}
+	h.hasStartedSending.Store(true)
+	return nil
+}
+
+// StopSendingMeasures sets the handler to stop sending measures through the measures channel.
+//
+// Returns:
+//
+// An error if the handler is not running.
+func (h *DefaultHandler) StopSendingMeasures() error {
+	h.handlerMutex.Lock()
 	defer h.handlerMutex.Unlock()
 	if !h.IsRunning() {
 		return ErrHandlerIsNotRunning
@@ -630,6 +2248,118 @@ func (h *DefaultHandler) GetMeasuresChannel() (<-chan *Measure, error) {
 	return h.measuresCh, nil
 }
 
+// Subscribe returns a new channel that receives each parsed measure as it arrives.
+//
+// Each subscriber gets its own channel, so a slow consumer never blocks the parsing loop. When the channel is full, the
+// subscriber overflow policy of the handler decides whether the newest or the oldest measure is dropped. The channel is
+// closed when the given context is cancelled or when the handler stops.
+//
+// Parameters:
+//
+// ctx: Context to unsubscribe from the measures.
+//
+// Returns:
+//
+// A read-only channel of measures, or an error if the handler is not running.
+func (h *DefaultHandler) Subscribe(ctx context.Context) (<-chan *Measure, error) {
+	h.handlerMutex.Lock()
+	defer h.handlerMutex.Unlock()
+	if !h.IsRunning() {
+		return nil, ErrHandlerIsNotRunning
+	}
+
+	// Register the subscriber
+	ch := make(chan *Measure, h.subscriberBufferSize)
+	h.subscribersMutex.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.subscribersMutex.Unlock()
+
+	// Unsubscribe when the context is cancelled or the handler stops
+	doneCh := h.doneCh
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-doneCh:
+		}
+		h.unsubscribe(ch)
+	}()
+	return ch, nil
+}
+
+// unsubscribe removes the given subscriber and closes its channel.
+//
+// Parameters:
+//
+// ch: The subscriber channel to remove.
+func (h *DefaultHandler) unsubscribe(ch chan *Measure) {
+	h.subscribersMutex.Lock()
+	defer h.subscribersMutex.Unlock()
+	if _, ok := h.subscribers[ch]; !ok {
+		return
+	}
+	delete(h.subscribers, ch)
+	close(ch)
+}
+
+// publishMeasure sends the given measure to every subscriber without blocking.
+//
+// Parameters:
+//
+// measure: The measure to send.
+func (h *DefaultHandler) publishMeasure(measure *Measure) {
+	h.subscribersMutex.Lock()
+	defer h.subscribersMutex.Unlock()
+
+	// Check if there are subscribers before copying the measure
+	if len(h.subscribers) == 0 {
+		return
+	}
+	measure = h.exportMeasure(measure)
+	for ch := range h.subscribers {
+		select {
+		case ch <- measure:
+			continue
+		default:
+		}
+
+		// The subscriber channel is full
+		if h.subscriberOverflowPolicy == SubscriberOverflowPolicyDropOldest {
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- measure:
+			default:
+			}
+		}
+	}
+}
+
+// RotationEvents returns the channel that receives an event each time the RPLiDAR completes a full rotation.
+//
+// The same channel is returned across calls and it's never closed. Events are dropped if there's no reader, and the
+// first partial rotation before the first sync bit will not fire the event.
+//
+// Returns:
+//
+// A read-only channel of rotation completed events.
+func (h *DefaultHandler) RotationEvents() <-chan RotationCompleted {
+	return h.rotationEventsCh
+}
+
+// ParseErrors returns the channel that receives an error each time a line fails to parse once the measurement data
+// started, wrapping the underlying parse error and including the offending line.
+//
+// The same channel is returned across calls and it's never closed. Errors are dropped if there's no reader.
+//
+// Returns:
+//
+// A read-only channel of parse errors.
+func (h *DefaultHandler) ParseErrors() <-chan error {
+	return h.parseErrorsCh
+}
+
 // WaitUntilReady waits until the handler is ready to process measures.
 //
 // Parameters:
@@ -655,6 +2385,36 @@ func (h *DefaultHandler) WaitUntilReady(ctx context.Context) error {
 	}
 }
 
+// WaitForFirstRotation waits until the first full rotation has been observed, which is when the first sync bit
+// measure is parsed.
+//
+// Parameters:
+//
+// ctx: Context for managing cancellation and timeouts.
+//
+// Returns:
+//
+// An error if the handler is not running, if it stops before the first rotation, or if the context is done.
+func (h *DefaultHandler) WaitForFirstRotation(ctx context.Context) error {
+	h.handlerMutex.Lock()
+	if !h.IsRunning() {
+		h.handlerMutex.Unlock()
+		return ErrHandlerIsNotRunning
+	}
+	readyCh := h.readyCh
+	doneCh := h.doneCh
+	h.handlerMutex.Unlock()
+
+	select {
+	case <-readyCh:
+		return nil
+	case <-doneCh:
+		return ErrHandlerIsNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // scanLines reads lines from the provided reader and processes them using the given lineHandler.
 //
 // Parameters:
@@ -663,6 +2423,7 @@ func (h *DefaultHandler) WaitUntilReady(ctx context.Context) error {
 // tag: Tag to identify the source of the lines (e.g., "stdout" or "stderr").
 // r: Reader to read lines from.
 // lineHandler: Function to process each line.
+// loggerProducer: Logger producer used to log the skipped and received lines.
 //
 // Returns:
 //
@@ -672,6 +2433,7 @@ func (h *DefaultHandler) scanLines(
 	tag string,
 	r interface{ Read([]byte) (int, error) },
 	lineHandler func(string) error,
+	loggerProducer goconcurrentlogger.LoggerProducer,
 ) error {
 	// Check if the lineHandler is nil
 	if lineHandler == nil {
@@ -682,13 +2444,31 @@ func (h *DefaultHandler) scanLines(
 	sc := bufio.NewScanner(r)
 
 	// Set the buffer size
-	buf := make([]byte, 0, InitialSizeBuffer)
-	sc.Buffer(buf, MaxSizeBuffer)
+	buf := make([]byte, 0, h.initialBufferSize)
+	sc.Buffer(buf, h.maxBufferSize)
+
+	// Skip the lines longer than the max line length instead of buffering them
+	if h.maxLineLength > 0 {
+		sc.Split(
+			newLineLengthGuard(
+				h.maxLineLength,
+				func(length int) {
+					loggerProducer.Warning(
+						fmt.Sprintf(
+							"Skipping line of %d bytes from %s exceeding the max line length",
+							length,
+							tag,
+						),
+					)
+				},
+			),
+		)
+	}
 
 	for sc.Scan() {
 		select {
 		case <-ctx.Done():
-			h.handlerLoggerProducer.Info(
+			loggerProducer.Info(
 				fmt.Sprintf(
 					"Context done while reading lines from %s: %v",
 					tag,
@@ -698,12 +2478,17 @@ func (h *DefaultHandler) scanLines(
 			// Return context error
 			return ctx.Err()
 		default:
+			// Capture the raw stdout line before handling it
+			if tag == StdoutTag {
+				h.captureLine(sc.Text())
+			}
+
 			// Read the line
 			line := strings.TrimSpace(sc.Text())
 
 			// Process the line
-			if h.handlerLoggerProducer.IsDebug() {
-				h.handlerLoggerProducer.Debug(
+			if loggerProducer.IsDebug() {
+				loggerProducer.Debug(
 					fmt.Sprintf(
 						"Received line from %s: %s",
 						tag,
@@ -736,51 +2521,199 @@ func (h *DefaultHandler) scanLines(
 //
 // An error if any issue occurs during processing the line.
 func (h *DefaultHandler) handleStdoutLine(line string) error {
-	// Increment the stdout lines read counter
-	h.stdoutLinesRead++
+	measure, err := h.parseStdoutLine(line)
+	return h.handleParsedStdoutLine(line, measure, err)
+}
 
-	// Check if the message should be ignored
-	if h.stdoutLinesRead <= IgnoreFirstStdoutMessages {
-		return nil
+// parseStdoutLine creates a measure from a single line from stdout. It doesn't change the state of the handler, so it
+// can be called concurrently.
+//
+// Parameters:
+//
+// line: The line to parse.
+//
+// Returns:
+//
+// The parsed measure, or an error if the line is not a valid measure.
+func (h *DefaultHandler) parseStdoutLine(line string) (*Measure, error) {
+	// Check if the measures are pooled
+	if h.measurePool == nil {
+		return NewMeasureFromStringWithConfig(
+			line,
+			h.parserConfig,
+			h.isUpsideDown,
+			h.angleAdjustment,
+		)
 	}
 
-	// Create a measure from the given string
-	measure, err := NewMeasureFromString(
+	measure := h.measurePool.Get().(*Measure)
+	if err := parseMeasureInto(
+		measure,
 		line,
+		h.parserConfig,
 		h.isUpsideDown,
 		h.angleAdjustment,
-	)
-	if err != nil {
-		h.handlerLoggerProducer.Warning(
-			fmt.Sprintf(
-				"Failed to parse measure: %v",
-				err,
-			),
-		)
-		return nil // Ignore parsing errors
+	); err != nil {
+		h.measurePool.Put(measure)
+		return nil, err
 	}
+	return measure, nil
+}
+
+// handleParsedStdoutLine processes a single line from stdout that has already been parsed. The lines must be handled in
+// the order they were read, since the sync bit handling depends on it.
+//
+// Parameters:
+//
+// line: The line to process.
+// measure: The measure parsed from the line, or nil if it couldn't be parsed.
+// err: The error returned when parsing the line.
+//
+// Returns:
+//
+// An error if any issue occurs during processing the line.
+func (h *DefaultHandler) handleParsedStdoutLine(line string, measure *Measure, err error) error {
+	// Return the measure to the pool unless it's stored
+	isStored := false
+	defer func() {
+		if !isStored {
+			h.releaseMeasure(measure)
+		}
+	}()
+
+	// Increment the stdout lines read counter
+	h.stdoutLinesRead++
+	h.linesRead.Add(1)
+
+	// Check if the message should be ignored
+	if h.stdoutLinesRead <= h.ignoreFirstStdoutMessages {
+		h.recordBannerLine(line)
+		return h.checkDeviceHealth(line)
+	}
+
+	if err != nil {
+		// Lines that don't have the shape of a measure before the first valid one are banner or log lines
+		if !h.isReceivingMeasures {
+			h.recordBannerLine(line)
+			if h.handlerLoggerProducer.IsDebug() {
+				h.handlerLoggerProducer.Debug(
+					fmt.Sprintf(
+						"Skipping non-measure line: %s",
+						line,
+					),
+				)
+			}
+			return h.checkDeviceHealth(line)
+		}
+
+		h.parseErrors.Add(1)
+		h.handlerLoggerProducer.Warning(
+			fmt.Sprintf(
+				"Failed to parse measure: %v",
+				err,
+			),
+		)
+
+		// Notify the parse error without blocking
+		select {
+		case h.parseErrorsCh <- fmt.Errorf("failed to parse line %q: %w", line, err):
+		default:
+		}
+
+		// Send the parse error event
+		if eventSink := h.getEventSink(); eventSink != nil {
+			eventSink.OnParseError(line, err)
+		}
+		return nil // Ignore parsing errors
+	}
+
+	h.measuresParsed.Add(1)
+	h.lastMeasureAt.Store(time.Now().UnixNano())
+	measure.qualityScale = h.qualityScale
+
+	// Timestamp the measure with the custom clock if it's set
+	if h.clock != nil {
+		measure.timestamp = h.clock()
+	}
+
+	// Switch into data mode once the first valid measure is seen
+	if !h.isReceivingMeasures {
+		h.isReceivingMeasures = true
+		h.handlerLoggerProducer.Info(HandlerReceivingMeasuresMessage)
+	}
+
+	// Check if the RPLiDAR has completed a full rotation
+	if measure.IsRotationCompleted() {
+		if h.handlerLoggerProducer.IsDebug() {
+			h.handlerLoggerProducer.Debug("Full rotation completed.")
+		}
 
-	// Check if the RPLiDAR has completed a full rotation
-	if measure.IsRotationCompleted() {
-		if h.handlerLoggerProducer.IsDebug() {
-			h.handlerLoggerProducer.Debug("Full rotation completed.")
-		}
-
 		// Signal that the handler is ready after the first full rotation
 		if !h.rplidarApplicationStarted.Load() {
 			h.rplidarApplicationStarted.Store(true)
 			close(h.readyCh)
 			h.handlerLoggerProducer.Info(HandlerReadyMessage)
 		}
+
+		// Count the rotation
+		rotationCount := h.rotationCount.Add(1)
+
+		// Send the rotation event
+		if eventSink := h.getEventSink(); eventSink != nil {
+			eventSink.OnRotation(rotationCount, measure.GetTimestamp())
+		}
+
+		// Clear the measures accumulated during the previous rotation
+		if h.accumulateMeasures {
+			h.measuresMutex.Lock()
+			for index := range h.accumulatedMeasures {
+				h.accumulatedMeasures[index] = h.accumulatedMeasures[index][:0]
+			}
+			h.measuresMutex.Unlock()
+		}
+
+		// Record the rotation timestamp to compute the scan frequency
+		h.recordRotationTimestamp(measure.GetTimestamp())
+
+		// Keep the completed scan in the scan history
+		h.recordScanHistory()
+
+		// Notify the rotation without blocking
+		select {
+		case h.rotationEventsCh <- RotationCompleted{}:
+		default:
+		}
+
+		// Invoke the rotation callback with a snapshot of the completed scan in a separate goroutine
+		h.callbacksMutex.Lock()
+		onRotationComplete := h.onRotationComplete
+		h.callbacksMutex.Unlock()
+		if onRotationComplete != nil {
+			scan := h.GetMeasures()
+			go onRotationComplete(scan)
+		}
 	}
 
-	// Check if the distance is valid
-	if measure.GetDistance() < 0 {
+	// Check if the distance is valid and outside the dead zone
+	if measure.GetDistance() < 0 || measure.GetDistance() < h.minDistanceLimit {
+		h.outOfRangeDropped.Add(1)
 		return nil
 	}
 
+	// Lock the measures for writing
+	h.measuresMutex.Lock()
+
 	// Check if the quality is below the minimum quality
 	if measure.GetQuality() < h.minimumQuality {
+		h.measuresMutex.Unlock()
+		h.qualityDropped.Add(1)
+		return nil
+	}
+
+	// Check if the angle is within a masked range
+	if h.isMaskedAngle(measure.GetAngle()) {
+		h.measuresMutex.Unlock()
+		h.maskedDropped.Add(1)
 		return nil
 	}
 
@@ -789,17 +2722,35 @@ func (h *DefaultHandler) handleStdoutLine(line string) error {
 		measure.distance = h.maxDistanceLimit
 	}
 
-	// Lock the measures for writing
-	h.measuresMutex.Lock()
-
-	// Store the measure in the measures
+	// Blend the distance with the one stored for the same angle if the smoothing is enabled
 	angle := int(measure.GetAngle()) % 360
+	if previous := h.measures[angle]; h.smoothingAlpha < NoSmoothingAlpha && previous != nil &&
+		previous.GetDistance() != 0 && measure.GetDistance() != 0 {
+		measure.distance = h.smoothingAlpha*measure.GetDistance() + (1-h.smoothingAlpha)*previous.GetDistance()
+	}
+
+	// Store the measure in the measures, returning the overwritten one to the pool
+	if previous := h.measures[angle]; previous != nil && previous != measure {
+		h.releaseMeasure(previous)
+	}
 	h.measures[angle] = measure
+	isStored = true
+
+	// Accumulate the measure with the other returns of the same degree during the current rotation
+	if h.accumulateMeasures {
+		h.accumulatedMeasures[angle] = append(h.accumulatedMeasures[angle], measure)
+	}
+
+	// Store the measure in the finer angular resolution grid
+	if h.fineMeasures != nil {
+		bucket := int(measure.GetAngle()*float64(h.bucketsPerDegree)) % len(h.fineMeasures)
+		h.fineMeasures[bucket] = measure
+	}
 
 	// Send the measure through the channel if it has started sending
 	if h.hasStartedSending.Load() {
 		select {
-		case h.measuresCh <- measure:
+		case h.measuresCh <- h.exportMeasure(measure):
 		default:
 			if h.handlerLoggerProducer.IsDebug() {
 				h.handlerLoggerProducer.Debug("Measures channel is full, skipping sending measures.")
@@ -809,9 +2760,129 @@ func (h *DefaultHandler) handleStdoutLine(line string) error {
 
 	// Unlock the measures
 	h.measuresMutex.Unlock()
+
+	// Send the measure to the subscribers
+	h.publishMeasure(measure)
+	return nil
+}
+
+// SetMinQuality sets the minimum quality for a measure to be stored, so it can be tuned while the handler is running.
+//
+// Parameters:
+//
+// minimumQuality: Minimum quality for a valid measurement.
+func (h *DefaultHandler) SetMinQuality(minimumQuality int) {
+	h.measuresMutex.Lock()
+	defer h.measuresMutex.Unlock()
+	h.minimumQuality = minimumQuality
+}
+
+// SetMaxDistanceLimit sets the maximum distance limit for valid measurements, so it can be tuned while the handler is
+// running without losing the current scan.
+//
+// Parameters:
+//
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+//
+// Returns:
+//
+// An error if the max distance limit is not valid.
+func (h *DefaultHandler) SetMaxDistanceLimit(maxDistanceLimit float64) error {
+	// Check if the max distance limit is valid
+	if maxDistanceLimit <= 0 {
+		return ErrInvalidMaxDistanceLimit
+	}
+
+	h.measuresMutex.Lock()
+	defer h.measuresMutex.Unlock()
+	h.maxDistanceLimit = maxDistanceLimit
+	return nil
+}
+
+// SetSmoothingAlpha sets the alpha of the exponential moving average that blends each new distance with the one stored
+// for the same angle, reducing the jitter between rotations.
+//
+// Parameters:
+//
+// alpha: Weight of the new distance within (0, 1], where 1 disables the smoothing.
+//
+// Returns:
+//
+// An error if the alpha is not valid.
+func (h *DefaultHandler) SetSmoothingAlpha(alpha float64) error {
+	// Check if the alpha is valid
+	if alpha <= 0 || alpha > 1 {
+		return ErrInvalidSmoothingAlpha
+	}
+
+	h.measuresMutex.Lock()
+	defer h.measuresMutex.Unlock()
+	h.smoothingAlpha = alpha
 	return nil
 }
 
+// SetOnRotationComplete sets the callback invoked with a snapshot of the scan each time the RPLiDAR completes a full
+// rotation. The callback runs in a separate goroutine to avoid blocking the parsing of the measures.
+//
+// Parameters:
+//
+// fn: The callback to invoke, or nil to remove it.
+func (h *DefaultHandler) SetOnRotationComplete(fn func(scan *[360]*Measure)) {
+	h.callbacksMutex.Lock()
+	defer h.callbacksMutex.Unlock()
+	h.onRotationComplete = fn
+}
+
+// SetEventSink sets the sink that receives the structured events of the handler, such as the completed rotations, the
+// parse errors and the ultra_simple process exits.
+//
+// Parameters:
+//
+// eventSink: The event sink, or nil to remove it.
+func (h *DefaultHandler) SetEventSink(eventSink EventSink) {
+	h.callbacksMutex.Lock()
+	defer h.callbacksMutex.Unlock()
+	h.eventSink = eventSink
+}
+
+// getEventSink returns the sink that receives the structured events of the handler.
+//
+// Returns:
+//
+// The event sink, or nil if it's not set.
+func (h *DefaultHandler) getEventSink() EventSink {
+	h.callbacksMutex.Lock()
+	defer h.callbacksMutex.Unlock()
+	return h.eventSink
+}
+
+// GetStats returns a snapshot of the line and measure counters of the current run.
+//
+// Returns:
+//
+// The handler stats.
+func (h *DefaultHandler) GetStats() HandlerStats {
+	return HandlerStats{
+		LinesRead:          h.linesRead.Load(),
+		MeasuresParsed:     h.measuresParsed.Load(),
+		ParseErrors:        h.parseErrors.Load(),
+		OutOfRangeDropped:  h.outOfRangeDropped.Load(),
+		QualityDropped:     h.qualityDropped.Load(),
+		MaskedDropped:      h.maskedDropped.Load(),
+		RotationsCompleted: h.rotationCount.Load(),
+	}
+}
+
+// GetRotationCount returns the number of full rotations completed since the current run started, counted on each
+// measure with the sync bit. It can be polled to act every N rotations, or to detect a stalled spin.
+//
+// Returns:
+//
+// The number of rotations completed.
+func (h *DefaultHandler) GetRotationCount() uint64 {
+	return h.rotationCount.Load()
+}
+
 // GetMeasures returns a copy of the current measures.
 //
 // Returns:
@@ -824,10 +2895,134 @@ func (h *DefaultHandler) GetMeasures() *[360]*Measure {
 
 	// Create a copy of the measures
 	measuresCopy := [360]*Measure{}
-	copy(measuresCopy[:], h.measures[:])
+	h.copyMeasures(measuresCopy[:], h.measures[:])
+	h.removeStaleMeasures(measuresCopy[:])
 	return &measuresCopy
 }
 
+// WithMeasures invokes the callback with the current measures while holding the measures read lock, avoiding the copy
+// made by GetMeasures. The callback must not retain the array nor the measures, must not
+// modify them, and must return quickly, since the measures can't be stored while it runs. The measures older than the
+// measure TTL are not removed.
+//
+// The callback must not call any method of the handler: most of them take the measures read lock again, which
+// deadlocks as soon as the goroutine that stores the measures is waiting for the write lock. The free functions that
+// take the measures, such as GetNearestObstacle, IsObstacleWithin or GetAverageDistanceFromDirection, can be used
+// instead, with the max distance limit read by GetMaxDistanceLimit before calling WithMeasures where they need it.
+//
+// Parameters:
+//
+// fn: The callback to read the current measures.
+func (h *DefaultHandler) WithMeasures(fn func(measures *[360]*Measure)) {
+	// Lock the measures for reading
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+
+	fn(&h.measures)
+}
+
+// ClearMeasures discards the accumulated scan, so it's rebuilt from scratch without restarting the handler.
+func (h *DefaultHandler) ClearMeasures() {
+	// Lock the measures for writing
+	h.measuresMutex.Lock()
+	defer h.measuresMutex.Unlock()
+
+	h.measures = [360]*Measure{}
+	h.fineMeasures = nil
+	if h.bucketsPerDegree > 1 {
+		h.fineMeasures = make([]*Measure, 360*h.bucketsPerDegree)
+	}
+	h.accumulatedMeasures = [360][]*Measure{}
+}
+
+// now returns the current time of the clock set with WithClock, or of the system clock if none is set.
+//
+// Returns:
+//
+// The current time.
+func (h *DefaultHandler) now() time.Time {
+	// Check if a custom clock is set
+	if h.clock != nil {
+		return h.clock()
+	}
+	return time.Now()
+}
+
+// removeStaleMeasures sets to nil the measures older than the measure TTL.
+//
+// Parameters:
+//
+// measures: The measures to remove the stale ones from.
+func (h *DefaultHandler) removeStaleMeasures(measures []*Measure) {
+	// Check if the measure TTL is disabled
+	if h.measureTTL <= 0 {
+		return
+	}
+
+	now := h.now()
+	for index, measure := range measures {
+		if measure != nil && now.Sub(measure.GetTimestamp()) > h.measureTTL {
+			measures[index] = nil
+		}
+	}
+}
+
+// GetMeasuresAtAngle returns all the measures of the given degree received during the current rotation.
+//
+// Parameters:
+//
+// angle: The degree to get the measures for.
+//
+// Returns:
+//
+// A copy of the measures of the given degree, or nil if the angle is not in [0, 360) or the accumulation mode is
+// disabled.
+func (h *DefaultHandler) GetMeasuresAtAngle(angle int) []*Measure {
+	if !h.accumulateMeasures || angle < 0 || angle >= 360 {
+		return nil
+	}
+
+	// Lock the measures for reading
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+
+	return append([]*Measure(nil), h.accumulatedMeasures[angle]...)
+}
+
+// GetFineMeasures returns a copy of the current measures at the configured angular resolution.
+//
+// Returns:
+//
+// A copy of the current measures with 360 times the buckets per degree entries, indexed by angle times the buckets per
+// degree.
+func (h *DefaultHandler) GetFineMeasures() []*Measure {
+	return h.getGridMeasures()
+}
+
+// getGridMeasures returns a copy of the current measures at the configured angular resolution, used by the analysis
+// helpers so they consider every bucket within each degree.
+//
+// Returns:
+//
+// A copy of the current measures grid.
+func (h *DefaultHandler) getGridMeasures() []*Measure {
+	// Lock the measures for reading
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+
+	// Check if the finer angular resolution grid is used
+	var measuresCopy []*Measure
+	if h.fineMeasures == nil {
+		measuresCopy = make([]*Measure, len(h.measures))
+		h.copyMeasures(measuresCopy, h.measures[:])
+	} else {
+		measuresCopy = make([]*Measure, len(h.fineMeasures))
+		copy(measuresCopy, h.fineMeasures)
+	}
+	h.removeStaleMeasures(measuresCopy)
+	return measuresCopy
+}
+
 // GetAverageDistanceFromAngle calculates the average distance for a given angle.
 //
 // Parameters:
@@ -843,95 +3038,649 @@ func (h *DefaultHandler) GetAverageDistanceFromAngle(
 	width int,
 ) (float64, error) {
 	// Get the current measures
-	measures := h.GetMeasures()
+	measures := h.getGridMeasures()
 
-	return GetAverageDistanceFromAngle(
+	return averageDistanceFromAngle(
 		measures,
 		middleAngle,
 		width,
 	)
 }
 
-// GetAverageDistanceFromDirection calculates the average distance for a given direction.
+// GetQualityWeightedAverageFromAngle calculates the average distance for a given angle, weighting each distance by its
+// quality.
 //
 // Parameters:
 //
+// middleAngle: The middle angle to calculate the average distance for.
 // width: The sum of the angles to consider with both sides and the middle angle.
-// direction: The direction to calculate the average distance for.
 //
 // Returns:
 //
-// The average distance for the specified direction, or an error if the direction is not valid.
-func (h *DefaultHandler) GetAverageDistanceFromDirection(
+// The quality-weighted average distance for the specified angle, or an error if the angle is not valid.
+func (h *DefaultHandler) GetQualityWeightedAverageFromAngle(
+	middleAngle int,
 	width int,
-	direction CardinalDirection,
 ) (float64, error) {
 	// Get the current measures
-	measures := h.GetMeasures()
+	measures := h.getGridMeasures()
 
-	return GetAverageDistanceFromDirection(
+	return qualityWeightedAverageFromAngle(
 		measures,
+		middleAngle,
 		width,
-		direction,
 	)
 }
 
-// GetAverageDistancesFromDirections calculates the average distances for the specified directions.
+// GetDistanceStdDevFromAngle calculates the mean and the sample standard deviation of the distances for a given angle.
 //
 // Parameters:
 //
+// middleAngle: The middle angle to calculate the standard deviation for.
 // width: The sum of the angles to consider with both sides and the middle angle.
-// directions: The directions to calculate the average distances for.
 //
 // Returns:
 //
-// A map with directions as keys and their average distances as values, or an error if any direction is not valid.
-func (h *DefaultHandler) GetAverageDistancesFromDirections(
+// The mean and the sample standard deviation of the distances for the specified angle, or an error if the angle is not
+// valid.
+func (h *DefaultHandler) GetDistanceStdDevFromAngle(
+	middleAngle int,
 	width int,
-	directions ...CardinalDirection,
-) (map[CardinalDirection]float64, error) {
+) (mean, stddev float64, err error) {
 	// Get the current measures
-	measures := h.GetMeasures()
+	measures := h.getGridMeasures()
 
-	return GetAverageDistancesFromDirections(
+	return distanceStdDevFromAngle(
 		measures,
+		middleAngle,
 		width,
-		directions...,
 	)
 }
 
-// GetAverageDistancesFromAllDirections calculates the average distances for all cardinal directions.
+// GetAverageDistanceInRange calculates the average distance from the start angle to the end angle inclusive.
+//
+// Parameters:
+//
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range, which wraps around the 0/360 seam if it's less than the start angle.
+//
+// Returns:
+//
+// The average distance for the specified range, or an error if any angle is not valid.
+func (h *DefaultHandler) GetAverageDistanceInRange(
+	startAngle int,
+	endAngle int,
+) (float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistanceInRange(
+		measures,
+		startAngle,
+		endAngle,
+	)
+}
+
+// GetMedianDistanceFromAngle calculates the median distance for a given angle.
 //
 // Parameters:
 //
+// middleAngle: The middle angle to calculate the median distance for.
 // width: The sum of the angles to consider with both sides and the middle angle.
 //
 // Returns:
 //
-// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not valid.
-func (h *DefaultHandler) GetAverageDistancesFromAllDirections(
+// The median distance for the specified angle, or an error if the angle is not valid.
+func (h *DefaultHandler) GetMedianDistanceFromAngle(
+	middleAngle int,
 	width int,
-) (map[CardinalDirection]float64, error) {
+) (float64, error) {
 	// Get the current measures
-	measures := h.GetMeasures()
+	measures := h.getGridMeasures()
 
-	return GetAverageDistanceFromAllDirections(
+	return medianDistanceFromAngle(
 		measures,
+		middleAngle,
 		width,
 	)
 }
 
-// handleStderrLine processes a single line from stderr.
+// IsObstacleWithin checks if there's a valid measure within the given distance inside a window of angles. It doesn't
+// copy the measures and returns on the first obstacle found, so it's cheap to call at a high frequency.
 //
 // Parameters:
 //
-// line: The line to process.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// maxMm: The distance in millimeters within which a measure is an obstacle.
 //
 // Returns:
 //
-// An error if any issue occurs during processing the line.
-func (h *DefaultHandler) handleStderrLine(line string) error {
-	// Log the stderr line as a warning
-	h.handlerLoggerProducer.Warning(fmt.Sprintf("stderr: %s", line))
-	return nil
+// True if a valid measure within the distance is found, or false if there's none or the window is not valid.
+func (h *DefaultHandler) IsObstacleWithin(
+	middleAngle int,
+	width int,
+	maxMm float64,
+) bool {
+	// Lock the measures for reading
+	h.measuresMutex.RLock()
+	defer h.measuresMutex.RUnlock()
+
+	// Check if the finer angular resolution grid is used
+	measures := h.measures[:]
+	if h.fineMeasures != nil {
+		measures = h.fineMeasures
+	}
+
+	// Ignore the stale measures if the measure TTL is enabled
+	var isStale func(measure *Measure) bool
+	if h.measureTTL > 0 {
+		now := h.now()
+		isStale = func(measure *Measure) bool {
+			return now.Sub(measure.GetTimestamp()) > h.measureTTL
+		}
+	}
+	return isObstacleWithin(measures, middleAngle, width, maxMm, isStale)
+}
+
+// GetAverageDistanceFromDirection calculates the average distance for a given direction.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction is not valid.
+func (h *DefaultHandler) GetAverageDistanceFromDirection(
+	width int,
+	direction CardinalDirection,
+) (float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistanceFromDirection(
+		measures,
+		width,
+		direction,
+	)
+}
+
+// GetMeasuresInDirection collects the valid measures within the window centered on a given direction.
+//
+// Parameters:
+//
+// direction: The direction to center the window on.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The valid measures within the window sorted from its first angle to its last one, wrapping around the 0/360 seam, or
+// an error if the direction or the width is not valid.
+func (h *DefaultHandler) GetMeasuresInDirection(
+	direction CardinalDirection,
+	width int,
+) ([]*Measure, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return measuresInDirection(
+		measures,
+		direction,
+		width,
+	)
+}
+
+// GetAverageDistancesFromDirections calculates the average distances for the specified directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// directions: The directions to calculate the average distances for.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction is not valid. The
+// directions without valid measures are omitted from the map.
+func (h *DefaultHandler) GetAverageDistancesFromDirections(
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistancesFromDirections(
+		measures,
+		width,
+		directions...,
+	)
+}
+
+// GetAverageDistancesFromAllDirections calculates the average distances for all cardinal directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not valid.
+// The directions without valid measures are omitted from the map.
+func (h *DefaultHandler) GetAverageDistancesFromAllDirections(
+	width int,
+) (map[CardinalDirection]float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistancesFromDirections(
+		measures,
+		width,
+		CardinalDirections...,
+	)
+}
+
+// GetAverageDistancesWithWidths calculates the average distances for the specified directions, each one with its own
+// width.
+//
+// Parameters:
+//
+// widths: A map with the directions as keys and the sum of the angles to consider around them as values.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction or width is not
+// valid. The directions without valid measures are omitted from the map.
+func (h *DefaultHandler) GetAverageDistancesWithWidths(
+	widths map[CardinalDirection]int,
+) (map[CardinalDirection]float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistancesWithWidths(measures, widths)
+}
+
+// GetAverageDistancesFromPrimaryDirections calculates the average distances for the 8 primary cardinal directions.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with the primary cardinal directions as keys and their average distances as values, or an error if the width
+// is not valid. The directions without valid measures are omitted from the map.
+func (h *DefaultHandler) GetAverageDistancesFromPrimaryDirections(
+	width int,
+) (map[CardinalDirection]float64, error) {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return averageDistancesFromDirections(
+		measures,
+		width,
+		PrimaryCardinalDirections...,
+	)
+}
+
+// MarshalScanJSON returns the JSON representation of the current measures.
+//
+// Returns:
+//
+// The JSON encoded array of 360 measures indexed by angle, with the empty angles as null, or an error if it couldn't be
+// encoded.
+func (h *DefaultHandler) MarshalScanJSON() ([]byte, error) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return json.Marshal(measures)
+}
+
+// WriteScanCSV writes the current measures as CSV, one row per non-nil measure sorted by angle.
+//
+// Parameters:
+//
+// w: The writer to write the CSV to.
+//
+// Returns:
+//
+// An error if the CSV couldn't be written.
+func (h *DefaultHandler) WriteScanCSV(w io.Writer) error {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return WriteScanCSV(w, measures)
+}
+
+// GetPointCloud returns the current valid measures as Cartesian points.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func (h *DefaultHandler) GetPointCloud() [][2]float64 {
+	// Get the current measures
+	measures := h.getGridMeasures()
+
+	return pointCloud(measures, h.GetMaxDistanceLimit())
+}
+
+// GetPointCloudRobotFrame returns the current measures as Cartesian points in the robot frame, after applying the mount
+// pose of the RPLiDAR.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters in the robot frame.
+func (h *DefaultHandler) GetPointCloudRobotFrame() [][2]float64 {
+	points := h.GetPointCloud()
+	for index, point := range points {
+		points[index] = h.mountPose.Transform(point)
+	}
+	return points
+}
+
+// GetMountPose returns the mount pose of the RPLiDAR relative to the robot frame.
+//
+// Returns:
+//
+// The mount pose.
+func (h *DefaultHandler) GetMountPose() MountPose {
+	return h.mountPose
+}
+
+// GetNearestObstacle finds the closest valid measure of the current scan.
+//
+// Returns:
+//
+// The angle and distance of the closest valid measure, and false if there are no valid measures.
+func (h *DefaultHandler) GetNearestObstacle() (angle int, distance float64, ok bool) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetNearestObstacle(measures, h.GetMaxDistanceLimit())
+}
+
+// GetFarthestValidDistance finds the valid measure with the most clearance of the current scan.
+//
+// Returns:
+//
+// The angle and distance of the farthest valid measure, and false if there are no valid measures.
+func (h *DefaultHandler) GetFarthestValidDistance() (angle int, distance float64, ok bool) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetFarthestValidDistance(measures, h.GetMaxDistanceLimit())
+}
+
+// DetectClusters groups the contiguous valid measures of the current scan with similar distances.
+//
+// Parameters:
+//
+// maxGapDeg: The maximum angular gap in degrees between two measures of the same cluster.
+// maxDistJumpMm: The maximum distance jump in millimeters between two measures of the same cluster.
+//
+// Returns:
+//
+// The clusters of the current scan sorted by their start angle.
+func (h *DefaultHandler) DetectClusters(
+	maxGapDeg int,
+	maxDistJumpMm float64,
+) []Cluster {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return DetectClusters(measures, h.GetMaxDistanceLimit(), maxGapDeg, maxDistJumpMm)
+}
+
+// FreeCorridorWidth expands from the heading to both sides of the current scan until it hits a measure closer than the
+// clearance.
+//
+// Parameters:
+//
+// heading: The heading angle in degrees.
+// clearanceMm: The clearance in millimeters.
+//
+// Returns:
+//
+// The last clear angles at the left and at the right of the heading, or NoCorridorAngle at both sides if the heading or
+// the clearance is not valid, or if the heading itself is blocked.
+func (h *DefaultHandler) FreeCorridorWidth(
+	heading int,
+	clearanceMm float64,
+) (leftAngle, rightAngle int) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return FreeCorridorWidth(measures, h.GetMaxDistanceLimit(), heading, clearanceMm)
+}
+
+// GetInterpolatedScan fills the small gaps of the current scan by linearly interpolating the nearest valid measures.
+//
+// Parameters:
+//
+// maxGap: The maximum number of consecutive missing angles to fill.
+//
+// Returns:
+//
+// A copy of the current measures with the small gaps filled.
+func (h *DefaultHandler) GetInterpolatedScan(maxGap int) *[360]*Measure {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetInterpolatedScan(measures, h.GetMaxDistanceLimit(), maxGap)
+}
+
+// Snapshot captures the current measures as a Scan, which can be analyzed without holding the handler.
+//
+// Returns:
+//
+// A pointer to a Scan instance with a copy of the current measures.
+func (h *DefaultHandler) Snapshot() *Scan {
+	return &Scan{
+		measures:         *h.GetMeasures(),
+		maxDistanceLimit: h.GetMaxDistanceLimit(),
+		timestamp:        h.now(),
+	}
+}
+
+// GetScanResult captures the current measures alongside their metadata. The measures, the max distance limit and the
+// scan frequency are read while holding the measures lock, so no measure or rotation is processed in between.
+//
+// Returns:
+//
+// The current scan result.
+func (h *DefaultHandler) GetScanResult() ScanResult {
+	// Lock the measures for reading
+	h.measuresMutex.RLock()
+	measures := [360]*Measure{}
+	h.copyMeasures(measures[:], h.measures[:])
+	h.removeStaleMeasures(measures[:])
+	maxDistanceLimit := h.maxDistanceLimit
+	frequency := h.GetScanFrequency()
+	timestamp := h.now()
+	h.measuresMutex.RUnlock()
+
+	return newScanResult(&measures, maxDistanceLimit, frequency, timestamp)
+}
+
+// GetValidMeasures returns the valid measures of the current scan.
+//
+// Returns:
+//
+// The valid measures sorted by angle.
+func (h *DefaultHandler) GetValidMeasures() []*Measure {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetValidMeasures(measures, h.GetMaxDistanceLimit())
+}
+
+// HasMeasureAt checks if the given angle of the current scan has a valid measure.
+//
+// Parameters:
+//
+// angle: The angle to check in degrees.
+//
+// Returns:
+//
+// True if the angle is within [0, 360) and has a valid measure, false otherwise.
+func (h *DefaultHandler) HasMeasureAt(angle int) bool {
+	// Check if the angle is valid
+	if angle < 0 || angle >= 360 {
+		return false
+	}
+
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return isValidMeasure(measures[angle], h.GetMaxDistanceLimit())
+}
+
+// CoverageRatio calculates the fraction of the 360 angles of the current scan that have a valid measure.
+//
+// Returns:
+//
+// The fraction of angles with a valid measure, in [0, 1].
+func (h *DefaultHandler) CoverageRatio() float64 {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return CoverageRatio(measures, h.GetMaxDistanceLimit())
+}
+
+// CalibrateAngleOffset finds the strongest return of the current scan near the actual angle of a known reference object,
+// and calculates the offset that aligns it with that angle.
+//
+// Parameters:
+//
+// knownObjectActualAngle: The actual angle in degrees of the reference object.
+// searchWidth: The sum of the angles to search with both sides and the actual angle.
+//
+// Returns:
+//
+// The offset in degrees to add to the current angle adjustment, or an error if the angle or the width is not valid, or
+// if there are no valid measures within the search window.
+func (h *DefaultHandler) CalibrateAngleOffset(knownObjectActualAngle int, searchWidth int) (float64, error) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return CalibrateAngleOffset(measures, h.GetMaxDistanceLimit(), knownObjectActualAngle, searchWidth)
+}
+
+// GetDownsampledScan reduces the current scan to the nearest valid measure of each sector of step degrees.
+//
+// Parameters:
+//
+// step: The width of each sector in degrees.
+//
+// Returns:
+//
+// A slice with the representative of each sector, which is nil if the sector has no valid measures, or an error if the
+// step is not valid.
+func (h *DefaultHandler) GetDownsampledScan(step int) ([]*Measure, error) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetDownsampledScan(measures, h.GetMaxDistanceLimit(), step)
+}
+
+// GetPolarHistogram calculates the obstacle density of each sector of sectorDeg degrees of the current scan.
+//
+// Parameters:
+//
+// sectorDeg: The width of each sector in degrees.
+// thresholdMm: The distance in millimeters under which a measure is considered an obstacle.
+//
+// Returns:
+//
+// The obstacle density of each sector in clockwise order starting at 0 degrees, or an error if the sector width or the
+// threshold is not valid.
+func (h *DefaultHandler) GetPolarHistogram(sectorDeg int, thresholdMm float64) ([]float64, error) {
+	// Get the current measures
+	measures := h.GetMeasures()
+
+	return GetPolarHistogram(measures, h.GetMaxDistanceLimit(), sectorDeg, thresholdMm)
+}
+
+// resetRotationTimestamps clears the recorded rotation timestamps.
+func (h *DefaultHandler) resetRotationTimestamps() {
+	h.rotationsMutex.Lock()
+	defer h.rotationsMutex.Unlock()
+	h.rotationTimestamps = [ScanFrequencySamples]time.Time{}
+	h.rotationTimestampsCount = 0
+	h.rotationTimestampsIndex = 0
+}
+
+// recordRotationTimestamp stores the timestamp of a completed rotation in the ring buffer.
+//
+// Parameters:
+//
+// timestamp: The time at which the rotation was completed.
+func (h *DefaultHandler) recordRotationTimestamp(timestamp time.Time) {
+	h.rotationsMutex.Lock()
+	defer h.rotationsMutex.Unlock()
+	h.rotationTimestamps[h.rotationTimestampsIndex] = timestamp
+	h.rotationTimestampsIndex = (h.rotationTimestampsIndex + 1) % ScanFrequencySamples
+	if h.rotationTimestampsCount < ScanFrequencySamples {
+		h.rotationTimestampsCount++
+	}
+}
+
+// GetScanFrequency returns the scan frequency averaged over the most recent rotations.
+//
+// Returns:
+//
+// The scan frequency in Hz, or 0 if not enough rotations have been observed.
+func (h *DefaultHandler) GetScanFrequency() float64 {
+	h.rotationsMutex.Lock()
+	defer h.rotationsMutex.Unlock()
+
+	// At least two rotations are needed to measure a period
+	if h.rotationTimestampsCount < 2 {
+		return 0
+	}
+
+	// Get the oldest and newest timestamps in the ring buffer
+	newestIndex := (h.rotationTimestampsIndex - 1 + ScanFrequencySamples) % ScanFrequencySamples
+	oldestIndex := (h.rotationTimestampsIndex - h.rotationTimestampsCount + ScanFrequencySamples) % ScanFrequencySamples
+	elapsed := h.rotationTimestamps[newestIndex].Sub(h.rotationTimestamps[oldestIndex])
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(h.rotationTimestampsCount-1) / elapsed.Seconds()
+}
+
+// handleStderrLine processes a single line from stderr.
+//
+// Parameters:
+//
+// line: The line to process.
+//
+// Returns:
+//
+// An error if any issue occurs during processing the line.
+func (h *DefaultHandler) handleStderrLine(line string) error {
+	// Log the stderr line as a warning
+	h.handlerLoggerProducer.Warning(fmt.Sprintf("stderr: %s", line))
+
+	// Keep the most recent stderr lines
+	h.stderrMutex.Lock()
+	if len(h.recentStderr) >= StderrHistorySize {
+		h.recentStderr = append(h.recentStderr[:0], h.recentStderr[1:]...)
+	}
+	h.recentStderr = append(h.recentStderr, line)
+	h.stderrMutex.Unlock()
+
+	// Check if the line reports the device health
+	return h.checkDeviceHealth(line)
+}
+
+// GetRecentStderr returns the most recent stderr lines of ultra_simple.
+//
+// Returns:
+//
+// A copy of the most recent stderr lines, from oldest to newest.
+func (h *DefaultHandler) GetRecentStderr() []string {
+	h.stderrMutex.Lock()
+	defer h.stderrMutex.Unlock()
+	return append([]string(nil), h.recentStderr...)
 }
@@ -0,0 +1,397 @@
+package go_rplidar_sdk_handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// angleTolerance is the tolerance used to compare the transformed angles
+const angleTolerance = 1e-9
+
+// TestNewMeasureAngle checks the angle transforms of NewMeasure, alone and combined.
+func TestNewMeasureAngle(t *testing.T) {
+	tests := []struct {
+		name            string
+		rawAngle        float64
+		isUpsideDown    bool
+		angleAdjustment float64
+		hasSyncBit      bool
+		expectedAngle   float64
+	}{
+		{name: "plain", rawAngle: 10, expectedAngle: 10},
+		{name: "plain zero", rawAngle: 0, expectedAngle: 0},
+		{name: "adjustment", rawAngle: 10, angleAdjustment: 90, expectedAngle: 100},
+		{name: "adjustment wraps above 360", rawAngle: 359.5, angleAdjustment: 1, expectedAngle: 0.5},
+		{name: "negative adjustment", rawAngle: 10, angleAdjustment: -90, expectedAngle: 280},
+		{name: "negative adjustment wraps below 0", rawAngle: 0.2, angleAdjustment: -1, expectedAngle: 359.2},
+		{name: "adjustment of more than a turn", rawAngle: 45, angleAdjustment: -450, expectedAngle: 315},
+		{name: "fractional adjustment", rawAngle: 359.8, angleAdjustment: 0.5, expectedAngle: 0.3},
+		{name: "upside down 0", rawAngle: 0, isUpsideDown: true, expectedAngle: 0},
+		{name: "upside down 180", rawAngle: 180, isUpsideDown: true, expectedAngle: 180},
+		{name: "upside down 359.9", rawAngle: 359.9, isUpsideDown: true, expectedAngle: 0.1},
+		{name: "upside down 90", rawAngle: 90, isUpsideDown: true, expectedAngle: 270},
+		{
+			name:            "upside down with negative adjustment",
+			rawAngle:        90,
+			isUpsideDown:    true,
+			angleAdjustment: -90,
+			expectedAngle:   180,
+		},
+		{
+			name:            "upside down with adjustment wrapping above 360",
+			rawAngle:        10,
+			isUpsideDown:    true,
+			angleAdjustment: 90,
+			expectedAngle:   80,
+		},
+		{name: "sync", rawAngle: 0.5, hasSyncBit: true, expectedAngle: 0.5},
+		{name: "sync above 360", rawAngle: 360.5, hasSyncBit: true, expectedAngle: 0.5},
+		{name: "sync with adjustment", rawAngle: 359.5, angleAdjustment: 1, hasSyncBit: true, expectedAngle: 0.5},
+		{
+			name:            "sync with negative adjustment",
+			rawAngle:        0.2,
+			angleAdjustment: -1,
+			hasSyncBit:      true,
+			expectedAngle:   359.2,
+		},
+		{name: "sync upside down", rawAngle: 0.5, isUpsideDown: true, hasSyncBit: true, expectedAngle: 359.5},
+		{
+			name:            "sync upside down with negative adjustment",
+			rawAngle:        10,
+			isUpsideDown:    true,
+			angleAdjustment: -90,
+			hasSyncBit:      true,
+			expectedAngle:   260,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			measure, err := NewMeasure(
+				test.rawAngle,
+				1000,
+				47,
+				test.hasSyncBit,
+				test.isUpsideDown,
+				test.angleAdjustment,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Check the transformed angle, which must be within [0, 360)
+			angle := measure.GetAngle()
+			if angle < 0 || angle >= 360 {
+				t.Fatalf("angle %f is not within [0, 360)", angle)
+			}
+			if math.Abs(angle-test.expectedAngle) > angleTolerance {
+				t.Errorf("expected angle %f, got %f", test.expectedAngle, angle)
+			}
+
+			// Check the raw angle and the sync bit are kept
+			if measure.GetRawAngle() != test.rawAngle {
+				t.Errorf("expected raw angle %f, got %f", test.rawAngle, measure.GetRawAngle())
+			}
+			if measure.IsRotationCompleted() != test.hasSyncBit {
+				t.Errorf("expected rotation completed %t, got %t", test.hasSyncBit, measure.IsRotationCompleted())
+			}
+		})
+	}
+}
+
+// TestNewMeasureInvalidAngle checks that NewMeasure rejects the angles outside the reported range.
+func TestNewMeasureInvalidAngle(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawAngle   float64
+		hasSyncBit bool
+	}{
+		{name: "negative", rawAngle: -0.5},
+		{name: "360 without sync", rawAngle: 360},
+		{name: "negative with sync", rawAngle: -0.5, hasSyncBit: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMeasure(test.rawAngle, 1000, 47, test.hasSyncBit, false, 0)
+			if !errors.Is(err, ErrInvalidAngle) {
+				t.Errorf("expected ErrInvalidAngle for the angle %f, got %v", test.rawAngle, err)
+			}
+		})
+	}
+}
+
+// TestNewMeasureSyncBitKeepsAngle checks that the sync bit marks the start of a rotation without distorting the angle,
+// which used to be reported as -359.5 for a raw angle of 0.5.
+func TestNewMeasureSyncBitKeepsAngle(t *testing.T) {
+	measure, err := NewMeasure(0.5, 1000, 47, true, false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(measure.GetAngle()-0.5) > angleTolerance {
+		t.Errorf("expected angle 0.5, got %f", measure.GetAngle())
+	}
+	if !measure.IsRotationCompleted() {
+		t.Error("expected the sync measure to complete a rotation")
+	}
+
+	// Check the same measure parsed from an ultra_simple line
+	measure, err = NewMeasureFromString("S theta: 0.50 Dist: 1000.00 Q: 47", false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(measure.GetAngle()-0.5) > angleTolerance {
+		t.Errorf("expected angle 0.5 from the line, got %f", measure.GetAngle())
+	}
+	if !measure.IsRotationCompleted() {
+		t.Error("expected the sync line to complete a rotation")
+	}
+}
+
+// measureParser is the signature shared by the measure parsing paths
+type measureParser func(measure *Measure, measureStr string) error
+
+// measureParsers returns the fast and the flexible measure parsing paths with the default parser config.
+func measureParsers() map[string]measureParser {
+	return map[string]measureParser{
+		"fast": func(measure *Measure, measureStr string) error {
+			if !parsePlainMeasure(measure, measureStr, DefaultParserConfig, false, 0) {
+				return ErrMeasureFieldCount
+			}
+			return nil
+		},
+		"flexible": func(measure *Measure, measureStr string) error {
+			return parseFlexibleMeasure(measure, measureStr, DefaultParserConfig, false, 0)
+		},
+		"public": func(measure *Measure, measureStr string) error {
+			parsedMeasure, err := NewMeasureFromStringWithConfig(measureStr, DefaultParserConfig, false, 0)
+			if err != nil {
+				return err
+			}
+			*measure = *parsedMeasure
+			return nil
+		},
+	}
+}
+
+// TestParseMeasureSyncBit checks the standalone and the attached sync bit markers through every parsing path.
+func TestParseMeasureSyncBit(t *testing.T) {
+	tests := []struct {
+		name          string
+		measureStr    string
+		expectedSync  bool
+		expectedAngle float64
+	}{
+		{name: "standalone", measureStr: "S 1 2 3", expectedSync: true, expectedAngle: 1},
+		{name: "attached to the first field", measureStr: "S1 2 3", expectedSync: true, expectedAngle: 1},
+		{name: "attached to the last field", measureStr: "1 2 3S", expectedSync: true, expectedAngle: 1},
+		{name: "without sync", measureStr: "1 2 3", expectedAngle: 1},
+		{name: "extra whitespace", measureStr: "  S \t1  2 3 ", expectedSync: true, expectedAngle: 1},
+	}
+
+	for parserName, parse := range measureParsers() {
+		for _, test := range tests {
+			t.Run(parserName+"/"+test.name, func(t *testing.T) {
+				var measure Measure
+				if err := parse(&measure, test.measureStr); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if measure.GetAngle() != test.expectedAngle || measure.GetDistance() != 2 || measure.GetQuality() != 3 {
+					t.Errorf(
+						"expected angle %f, distance 2 and quality 3, got %f, %f and %d",
+						test.expectedAngle,
+						measure.GetAngle(),
+						measure.GetDistance(),
+						measure.GetQuality(),
+					)
+				}
+				if measure.IsRotationCompleted() != test.expectedSync {
+					t.Errorf("expected sync %t, got %t", test.expectedSync, measure.IsRotationCompleted())
+				}
+			})
+		}
+	}
+}
+
+// TestNewMeasureFromStringFormats checks the labelled and the bare measure formats printed by ultra_simple.
+func TestNewMeasureFromStringFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		measureStr       string
+		expectedAngle    float64
+		expectedDistance float64
+		expectedQuality  int
+		expectedSync     bool
+	}{
+		{name: "bare", measureStr: "1 2 3", expectedAngle: 1, expectedDistance: 2, expectedQuality: 3},
+		{
+			name:             "labelled",
+			measureStr:       "theta: 1 dist: 2 q: 3",
+			expectedAngle:    1,
+			expectedDistance: 2,
+			expectedQuality:  3,
+		},
+		{
+			name:             "labelled as printed by the SDK demo",
+			measureStr:       "theta: 123.45 Dist: 01234.00 Q: 47",
+			expectedAngle:    123.45,
+			expectedDistance: 1234,
+			expectedQuality:  47,
+		},
+		{
+			name:             "labelled with sync",
+			measureStr:       "S theta: 0.50 Dist: 01234.00 Q: 47",
+			expectedAngle:    0.5,
+			expectedDistance: 1234,
+			expectedQuality:  47,
+			expectedSync:     true,
+		},
+		{
+			name:             "labels attached to the values",
+			measureStr:       "theta:1 Dist:2 Q:3",
+			expectedAngle:    1,
+			expectedDistance: 2,
+			expectedQuality:  3,
+		},
+		{
+			name:             "bare with sync",
+			measureStr:       "S 12.50 400.00 47",
+			expectedAngle:    12.5,
+			expectedDistance: 400,
+			expectedQuality:  47,
+			expectedSync:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			measure, err := NewMeasureFromStringWithConfig(test.measureStr, DefaultParserConfig, false, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(measure.GetAngle()-test.expectedAngle) > angleTolerance {
+				t.Errorf("expected angle %f, got %f", test.expectedAngle, measure.GetAngle())
+			}
+			if measure.GetDistance() != test.expectedDistance {
+				t.Errorf("expected distance %f, got %f", test.expectedDistance, measure.GetDistance())
+			}
+			if measure.GetQuality() != test.expectedQuality {
+				t.Errorf("expected quality %d, got %d", test.expectedQuality, measure.GetQuality())
+			}
+			if measure.IsRotationCompleted() != test.expectedSync {
+				t.Errorf("expected sync %t, got %t", test.expectedSync, measure.IsRotationCompleted())
+			}
+		})
+	}
+}
+
+// TestNewMeasureFromStringErrors checks the errors of the invalid measure strings.
+func TestNewMeasureFromStringErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		measureStr    string
+		expectedError error
+	}{
+		{name: "missing field", measureStr: "theta: 1 dist: 2", expectedError: ErrMeasureFieldCount},
+		{name: "extra field", measureStr: "1 2 3 4", expectedError: ErrMeasureFieldCount},
+		{name: "invalid angle", measureStr: "theta: x dist: 2 q: 3", expectedError: ErrParseAngle},
+		{name: "invalid distance", measureStr: "1 x 3", expectedError: ErrParseDistance},
+		{name: "invalid quality", measureStr: "1 2 x", expectedError: ErrParseQuality},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMeasureFromStringWithConfig(test.measureStr, DefaultParserConfig, false, 0)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("expected %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+// BenchmarkNewMeasureFromString benchmarks parsing the plain lines, handled by the allocation-free path, and the
+// labelled lines, handled by the flexible path.
+func BenchmarkNewMeasureFromString(b *testing.B) {
+	benchmarks := []struct {
+		name       string
+		measureStr string
+	}{
+		{name: "plain", measureStr: "123.45 1234.00 47"},
+		{name: "plain with sync", measureStr: "S 0.50 1234.00 47"},
+		{name: "labelled", measureStr: "theta: 123.45 Dist: 01234.00 Q: 47"},
+		{name: "labelled with sync", measureStr: "S theta: 0.50 Dist: 01234.00 Q: 47"},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := NewMeasureFromString(benchmark.measureStr, false, 0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestWithMeasures checks that the callback gets the stored measures and can use the free functions while the measures
+// are being stored concurrently.
+func TestWithMeasures(t *testing.T) {
+	h := newTestLineHandler(t)
+	if err := h.handleStdoutLine("10.00 1000.00 47"); err != nil {
+		t.Fatalf("failed to handle the line: %v", err)
+	}
+
+	// Store the measures of a few rotations concurrently
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for _, line := range testScanLines(3, 1) {
+			_ = h.handleStdoutLine(line)
+		}
+	}()
+
+	maxDistanceLimit := h.GetMaxDistanceLimit()
+	for range 100 {
+		h.WithMeasures(func(measures *[360]*Measure) {
+			if _, _, ok := GetNearestObstacle(measures, maxDistanceLimit); !ok {
+				t.Error("expected a nearest obstacle")
+			}
+		})
+	}
+	<-doneCh
+}
+
+// TestWatchDataCountsFromItsStart checks that the data watchdog counts the data timeout from its own start while no
+// measure has been parsed, instead of from the zero time of the last parsed measure.
+func TestWatchDataCountsFromItsStart(t *testing.T) {
+	h := newTestLineHandler(t)
+	h.dataTimeout = 200 * time.Millisecond
+	h.lastMeasureAt.Store(0)
+
+	ctx, cancelFn := context.WithCancelCause(context.Background())
+	defer cancelFn(nil)
+	startedAt := time.Now()
+	go h.watchData(ctx, cancelFn)
+
+	// Check that the watchdog doesn't stop the run before the data timeout
+	time.Sleep(h.dataTimeout / 2)
+	if cause := context.Cause(ctx); cause != nil {
+		t.Fatalf("expected the watchdog to wait for the data timeout, got %v", cause)
+	}
+
+	waitFor(t, "the data timeout", func() bool {
+		return errors.Is(context.Cause(ctx), ErrDataTimeout)
+	})
+	if elapsed := time.Since(startedAt); elapsed < h.dataTimeout {
+		t.Errorf("expected the data timeout after at least %s, got %s", h.dataTimeout, elapsed)
+	}
+
+	// Check that the watchdog didn't mark a measure as parsed
+	if lastMeasureAt := h.lastMeasureAt.Load(); lastMeasureAt != 0 {
+		t.Errorf("expected no parsed measure, got %d", lastMeasureAt)
+	}
+}
@@ -1,169 +1,1700 @@
 package go_rplidar_sdk_handler
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
 )
 
-// GetAverageDistanceFromAngle calculates the average distance for a given list of angles.
+// isValidMeasure checks if the given measure is a valid return within the max distance limit.
 //
 // Parameters:
 //
-// measures: A pointer to an array of 360 Measure pointers indexed by angle.
-// middleAngle: The middle angle to start the averaging from.
+// measure: The measure to check.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+//
+// Returns:
+//
+// True if the measure is not nil, has a non-zero distance and quality, and is within the max distance limit.
+func isValidMeasure(measure *Measure, maxDistanceLimit float64) bool {
+	if measure == nil {
+		return false
+	}
+	if measure.GetDistance() == 0.0 || measure.GetQuality() == 0 {
+		return false
+	}
+	return measure.GetDistance() <= maxDistanceLimit
+}
+
+// normalizeAngle wraps the given angle into [0, 360), whatever the number of turns it's away from that range.
+//
+// Parameters:
+//
+// angle: The angle in degrees.
+//
+// Returns:
+//
+// The equivalent angle within [0, 360).
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360.0)
+	if angle < 0 {
+		angle += 360.0
+	}
+
+	// Check if the rounding of a tiny negative angle landed on 360
+	if angle >= 360.0 {
+		angle = 0
+	}
+	return angle
+}
+
+// rotateAngleByDegrees rotates the given angle by a whole number of degrees, rotating its integer part as an index and
+// keeping its fractional part untouched, so the degree it's stored at is exactly the rotated degree of the original one.
+//
+// Parameters:
+//
+// angle: The angle in degrees.
+// degrees: The whole number of degrees to rotate the angle by, which can be negative.
+//
+// Returns:
+//
+// The rotated angle within [0, 360).
+func rotateAngleByDegrees(angle float64, degrees int) float64 {
+	angle = normalizeAngle(angle)
+	whole := math.Floor(angle)
+	index := (int(whole) + degrees%360 + 360) % 360
+	return normalizeAngle(float64(index) + (angle - whole))
+}
+
+// newLineLengthGuard creates a split function that splits the lines like bufio.ScanLines, but discards the lines longer
+// than the max line length as they are read, so they are never buffered whole.
+//
+// Parameters:
+//
+// maxLineLength: The maximum length in bytes of a line.
+// onSkip: Function called with the length of each discarded line.
+//
+// Returns:
+//
+// The split function.
+func newLineLengthGuard(maxLineLength int, onSkip func(length int)) bufio.SplitFunc {
+	isDiscarding := false
+	discardedLength := 0
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		index := bytes.IndexByte(data, '\n')
+
+		// Check if the rest of a long line is being discarded
+		if isDiscarding {
+			if index < 0 {
+				discardedLength += len(data)
+				if atEOF {
+					isDiscarding = false
+					onSkip(discardedLength)
+				}
+				return len(data), nil, nil
+			}
+			isDiscarding = false
+			onSkip(discardedLength + index)
+			return index + 1, nil, nil
+		}
+
+		// Check if the line exceeds the max line length
+		if index > maxLineLength {
+			onSkip(index)
+			return index + 1, nil, nil
+		}
+		if index < 0 && len(data) > maxLineLength {
+			if atEOF {
+				onSkip(len(data))
+			} else {
+				isDiscarding = true
+				discardedLength = len(data)
+			}
+			return len(data), nil, nil
+		}
+		return bufio.ScanLines(data, atEOF)
+	}
+}
+
+// globSerialPorts lists the devices matching the given glob patterns.
+//
+// Parameters:
+//
+// patterns: The glob patterns of the devices.
+//
+// Returns:
+//
+// The sorted device paths, or an error if any pattern is malformed.
+func globSerialPorts(patterns []string) ([]string, error) {
+	var ports []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, matches...)
+	}
+	sort.Strings(ports)
+	return ports, nil
+}
+
+// checkAngleWindow checks the middle angle and the width of a window.
+//
+// Parameters:
+//
+// middleAngle: The middle angle of the window.
 // width: The sum of the angles to consider with both sides and the middle angle.
 //
 // Returns:
 //
-// The average distance for the specified angles, or an error if the width is not valid.
-func GetAverageDistanceFromAngle(
-	measures *[360]*Measure,
-	middleAngle int,
-	width int,
-) (float64, error) {
-	// Calculate the range of angles to consider
+// An error if the middle angle or the width is not valid.
+func checkAngleWindow(middleAngle int, width int) error {
+	// Check the middle angle
+	if middleAngle < 0 || middleAngle >= 360 {
+		return ErrInvalidAngle
+	}
+
+	// Check the width
 	if width%2 == 0 {
-		return 0, ErrAngleWidthMustBeOdd
+		return ErrAngleWidthMustBeOdd
 	}
 	if width < 1 {
-		return 0, ErrAngleWidthTooSmall
+		return ErrAngleWidthTooSmall
 	}
 	if width >= 360 {
-		return 0, ErrAngleWidthTooLarge
+		return ErrAngleWidthTooLarge
 	}
+	return nil
+}
 
-	// Check if the width is 1, in which case we only consider the middle angle
-	if width == 1 {
-		measure := measures[middleAngle]
-		if measure == nil {
-			return math.NaN(), nil
-		}
-		return measure.GetDistance(), nil
+// getAngleWindow calculates the angles to consider around a middle angle, wrapping around the 0/360 seam.
+//
+// Parameters:
+//
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The deduplicated angles of the window within [0, 360), or an error if the middle angle or the width is not valid.
+func getAngleWindow(middleAngle int, width int) ([]int, error) {
+	// Check the middle angle and the width
+	if err := checkAngleWindow(middleAngle, width); err != nil {
+		return nil, err
 	}
 
-	// Calculate the angles to consider
-	var angles []int
+	// Calculate the angles to consider, since the width is less than 360 each angle appears once
 	widthPerSide := (width - 1) / 2
-	leftAngle := middleAngle - widthPerSide
-	rightAngle := middleAngle + widthPerSide
-	if leftAngle < 0 {
-		for angle := 360 + leftAngle; angle < 360; angle++ {
-			angles = append(angles, angle)
-		}
-	}
-	if rightAngle >= 360 {
-		for angle := 0; angle <= rightAngle-360; angle++ {
-			angles = append(angles, angle)
-		}
-	}
-	for angle := max(leftAngle, 0); angle <= min(360, rightAngle); angle++ {
-		angles = append(angles, angle)
+	angles := make([]int, 0, width)
+	for offset := -widthPerSide; offset <= widthPerSide; offset++ {
+		angles = append(angles, (middleAngle+offset+360)%360)
 	}
+	return angles, nil
+}
 
-	// Calculate the average distance
-	var totalDistance float64
-	var count int
+// getValidWindowMeasures collects the measures with a non-zero distance and quality.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// angles: The angles to collect the measures from.
+//
+// Returns:
+//
+// The valid measures of every bucket within the given angles.
+func getValidWindowMeasures(measures []*Measure, angles []int) []*Measure {
+	bucketsPerDegree := len(measures) / 360
+	validMeasures := make([]*Measure, 0, len(angles)*bucketsPerDegree)
 	for _, angle := range angles {
-		measure := measures[angle]
-		if measure == nil {
-			continue
-		}
+		for bucket := angle * bucketsPerDegree; bucket < (angle+1)*bucketsPerDegree; bucket++ {
+			measure := measures[bucket]
+			if measure == nil {
+				continue
+			}
 
-		// Check the distance and quality
-		if measure.GetDistance() == 0.0 || measure.GetQuality() == 0 {
-			continue
+			// Check the distance and quality
+			if measure.GetDistance() == 0.0 || measure.GetQuality() == 0 {
+				continue
+			}
+			validMeasures = append(validMeasures, measure)
 		}
+	}
+	return validMeasures
+}
 
-		totalDistance += measure.GetDistance()
-		count++
+// getValidDistances collects the distances of the measures with a non-zero distance and quality.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// angles: The angles to collect the distances from.
+//
+// Returns:
+//
+// The valid distances of every bucket within the given angles.
+func getValidDistances(measures []*Measure, angles []int) []float64 {
+	validMeasures := getValidWindowMeasures(measures, angles)
+	distances := make([]float64, 0, len(validMeasures))
+	for _, measure := range validMeasures {
+		distances = append(distances, measure.GetDistance())
 	}
+	return distances
+}
 
-	// Check if the count is zero
-	if count == 0 {
-		return math.NaN(), nil
+// GetAverageDistanceFromAngle calculates the average distance for a given list of angles.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// middleAngle: The middle angle to start the averaging from.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The average distance for the specified angles, or an error if the middle angle or the width is not valid, or if
+// there are no valid measures within the window.
+func GetAverageDistanceFromAngle(
+	measures *[360]*Measure,
+	middleAngle int,
+	width int,
+) (float64, error) {
+	return averageDistanceFromAngle(measures[:], middleAngle, width)
+}
+
+// averageDistanceFromAngle calculates the average distance for a given list of angles over a grid of measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// middleAngle: The middle angle to start the averaging from.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The average distance for the specified angles, or an error if the middle angle or the width is not valid, or if
+// there are no valid measures within the window.
+func averageDistanceFromAngle(
+	measures []*Measure,
+	middleAngle int,
+	width int,
+) (float64, error) {
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return 0, err
 	}
 
-	// Return the average distance
-	return totalDistance / float64(count), nil
+	// Check if there are valid distances
+	distances := getValidDistances(measures, angles)
+	if len(distances) == 0 {
+		return 0, ErrNoValidMeasures
+	}
+	return meanDistance(distances), nil
 }
 
-// GetAverageDistanceFromDirection calculates the average distance for a given direction.
+// GetQualityWeightedAverageFromAngle calculates the average distance for a given list of angles, weighting each distance
+// by its quality.
 //
 // Parameters:
 //
 // measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// middleAngle: The middle angle of the window.
 // width: The sum of the angles to consider with both sides and the middle angle.
-// direction: The direction to calculate the average distance for.
 //
 // Returns:
 //
-// The average distance for the specified direction, or an error if the direction is not valid.
-func GetAverageDistanceFromDirection(
+// The quality-weighted average distance for the specified angles, or an error if the middle angle or the width is not
+// valid, or if there are no valid measures within the window.
+func GetQualityWeightedAverageFromAngle(
 	measures *[360]*Measure,
+	middleAngle int,
 	width int,
-	direction CardinalDirection,
 ) (float64, error) {
-	directionAngle := direction.Angle()
+	return qualityWeightedAverageFromAngle(measures[:], middleAngle, width)
+}
 
-	// Round the angle
-	if directionAngle >= 180 {
-		directionAngle = math.Ceil(directionAngle)
-	} else {
-		directionAngle = math.Floor(directionAngle)
+// qualityWeightedAverageFromAngle calculates the average distance for a given list of angles over a grid of measures,
+// weighting each distance by its quality.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The quality-weighted average distance for the specified angles, or an error if the middle angle or the width is not
+// valid, or if there are no valid measures within the window.
+func qualityWeightedAverageFromAngle(
+	measures []*Measure,
+	middleAngle int,
+	width int,
+) (float64, error) {
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return 0, err
 	}
 
-	return GetAverageDistanceFromAngle(
-		measures,
-		int(directionAngle),
-		width,
-	)
+	// Check if there are valid measures
+	validMeasures := getValidWindowMeasures(measures, angles)
+	if len(validMeasures) == 0 {
+		return 0, ErrNoValidMeasures
+	}
+
+	// Calculate the weighted average distance
+	var weightedDistance, totalQuality float64
+	for _, measure := range validMeasures {
+		weightedDistance += measure.GetDistance() * float64(measure.GetQuality())
+		totalQuality += float64(measure.GetQuality())
+	}
+	return weightedDistance / totalQuality, nil
 }
 
-// GetAverageDistancesFromDirections calculates the average distances for the specified directions.
+// GetDistanceStdDevFromAngle calculates the mean and the sample standard deviation of the distances for a given list of
+// angles.
 //
 // Parameters:
 //
 // measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// middleAngle: The middle angle of the window.
 // width: The sum of the angles to consider with both sides and the middle angle.
-// directions: The directions to calculate the average distances for.
 //
 // Returns:
 //
-// A map with directions as keys and their average distances as values, or an error if any direction is not valid.
-func GetAverageDistancesFromDirections(
+// The mean and the sample standard deviation of the distances for the specified angles, which is 0 if there's a single
+// valid measure, or an error if the middle angle or the width is not valid, or if there are no valid measures within
+// the window.
+func GetDistanceStdDevFromAngle(
 	measures *[360]*Measure,
+	middleAngle int,
 	width int,
-	directions ...CardinalDirection,
-) (map[CardinalDirection]float64, error) {
-	avgDistances := make(map[CardinalDirection]float64)
-	for _, direction := range directions {
-		avgDistance, err := GetAverageDistanceFromDirection(
-			measures, width, direction,
-		)
-		if err != nil {
-			return nil, err
-		}
-		avgDistances[direction] = avgDistance
+) (mean, stddev float64, err error) {
+	return distanceStdDevFromAngle(measures[:], middleAngle, width)
+}
+
+// distanceStdDevFromAngle calculates the mean and the sample standard deviation of the distances for a given list of
+// angles over a grid of measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The mean and the sample standard deviation of the distances for the specified angles, or an error if the middle
+// angle or the width is not valid, or if there are no valid measures within the window.
+func distanceStdDevFromAngle(
+	measures []*Measure,
+	middleAngle int,
+	width int,
+) (mean, stddev float64, err error) {
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return 0, 0, err
 	}
-	return avgDistances, nil
+
+	// Check if there are valid distances
+	distances := getValidDistances(measures, angles)
+	if len(distances) == 0 {
+		return 0, 0, ErrNoValidMeasures
+	}
+	mean = meanDistance(distances)
+
+	// Check if there are enough distances for the sample standard deviation
+	if len(distances) < 2 {
+		return mean, 0, nil
+	}
+
+	// Calculate the sample standard deviation
+	var squaredDeviations float64
+	for _, distance := range distances {
+		squaredDeviations += (distance - mean) * (distance - mean)
+	}
+	return mean, math.Sqrt(squaredDeviations / float64(len(distances)-1)), nil
 }
 
-// GetAverageDistanceFromAllDirections calculates the average distance for all cardinal directions.
+// meanDistance calculates the mean of the given distances.
+//
+// Parameters:
+//
+// distances: The distances to average, which must not be empty.
+//
+// Returns:
+//
+// The mean distance.
+func meanDistance(distances []float64) float64 {
+	var totalDistance float64
+	for _, distance := range distances {
+		totalDistance += distance
+	}
+	return totalDistance / float64(len(distances))
+}
+
+// getAngleRange calculates the angles from the start angle to the end angle inclusive, wrapping around the 0/360 seam
+// if the start angle is greater than the end angle.
+//
+// Parameters:
+//
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range.
+//
+// Returns:
+//
+// The angles of the range within [0, 360), or an error if any angle is not valid.
+func getAngleRange(startAngle int, endAngle int) ([]int, error) {
+	// Check the angles
+	if startAngle < 0 || startAngle >= 360 || endAngle < 0 || endAngle >= 360 {
+		return nil, ErrInvalidAngle
+	}
+
+	// Calculate the angles to consider
+	length := (endAngle-startAngle+360)%360 + 1
+	angles := make([]int, 0, length)
+	for offset := 0; offset < length; offset++ {
+		angles = append(angles, (startAngle+offset)%360)
+	}
+	return angles, nil
+}
+
+// GetAverageDistanceInRange calculates the average distance from the start angle to the end angle inclusive.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range, which wraps around the 0/360 seam if it's less than the start angle.
+//
+// Returns:
+//
+// The average distance for the specified range, or an error if any angle is not valid, or if there are no valid
+// measures within the range.
+func GetAverageDistanceInRange(
+	measures *[360]*Measure,
+	startAngle int,
+	endAngle int,
+) (float64, error) {
+	return averageDistanceInRange(measures[:], startAngle, endAngle)
+}
+
+// averageDistanceInRange calculates the average distance from the start angle to the end angle inclusive over a grid of
+// measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// startAngle: The first angle of the range.
+// endAngle: The last angle of the range, which wraps around the 0/360 seam if it's less than the start angle.
+//
+// Returns:
+//
+// The average distance for the specified range, or an error if any angle is not valid, or if there are no valid
+// measures within the range.
+func averageDistanceInRange(
+	measures []*Measure,
+	startAngle int,
+	endAngle int,
+) (float64, error) {
+	// Calculate the range of angles to consider
+	angles, err := getAngleRange(startAngle, endAngle)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check if there are valid distances
+	distances := getValidDistances(measures, angles)
+	if len(distances) == 0 {
+		return 0, ErrNoValidMeasures
+	}
+	return meanDistance(distances), nil
+}
+
+// GetMedianDistanceFromAngle calculates the median distance for a given list of angles.
 //
 // Parameters:
 //
 // measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// middleAngle: The middle angle of the window.
 // width: The sum of the angles to consider with both sides and the middle angle.
 //
 // Returns:
 //
-// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not valid.
-func GetAverageDistanceFromAllDirections(
+// The median distance for the specified angles, or an error if the middle angle or the width is not valid, or if
+// there are no valid measures within the window.
+func GetMedianDistanceFromAngle(
 	measures *[360]*Measure,
+	middleAngle int,
 	width int,
-) (map[CardinalDirection]float64, error) {
-	return GetAverageDistancesFromDirections(
-		measures,
-		width,
-		CardinalDirections...,
-	)
+) (float64, error) {
+	return medianDistanceFromAngle(measures[:], middleAngle, width)
+}
+
+// medianDistanceFromAngle calculates the median distance for a given list of angles over a grid of measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The median distance for the specified angles, or an error if the middle angle or the width is not valid, or if
+// there are no valid measures within the window.
+func medianDistanceFromAngle(
+	measures []*Measure,
+	middleAngle int,
+	width int,
+) (float64, error) {
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return 0, err
+	}
+
+	// Collect and sort the valid distances
+	distances := getValidDistances(measures, angles)
+	if len(distances) == 0 {
+		return 0, ErrNoValidMeasures
+	}
+	sort.Float64s(distances)
+
+	// Average the two middle distances if the count is even
+	middle := len(distances) / 2
+	if len(distances)%2 == 0 {
+		return (distances[middle-1] + distances[middle]) / 2, nil
+	}
+	return distances[middle], nil
+}
+
+// IsObstacleWithin checks if there's a valid measure within the given distance inside a window of angles.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// maxMm: The distance in millimeters within which a measure is an obstacle.
+//
+// Returns:
+//
+// True if a valid measure within the distance is found, or false if there's none or the window is not valid.
+func IsObstacleWithin(
+	measures *[360]*Measure,
+	middleAngle int,
+	width int,
+	maxMm float64,
+) bool {
+	return isObstacleWithin(measures[:], middleAngle, width, maxMm, nil)
+}
+
+// isObstacleWithin checks if there's a valid measure within the given distance inside a window of angles over a grid of
+// measures, returning on the first one found.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// middleAngle: The middle angle of the window.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// maxMm: The distance in millimeters within which a measure is an obstacle.
+// isIgnored: Optional function to ignore some measures, e.g. the stale ones.
+//
+// Returns:
+//
+// True if a valid measure within the distance is found, or false if there's none or the window is not valid.
+func isObstacleWithin(
+	measures []*Measure,
+	middleAngle int,
+	width int,
+	maxMm float64,
+	isIgnored func(measure *Measure) bool,
+) bool {
+	// Check the distance
+	if maxMm <= 0 {
+		return false
+	}
+
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return false
+	}
+
+	bucketsPerDegree := len(measures) / 360
+	for _, angle := range angles {
+		for bucket := angle * bucketsPerDegree; bucket < (angle+1)*bucketsPerDegree; bucket++ {
+			measure := measures[bucket]
+			if measure == nil || (isIgnored != nil && isIgnored(measure)) {
+				continue
+			}
+
+			// Check the distance and quality
+			if measure.GetDistance() == 0.0 || measure.GetQuality() == 0 {
+				continue
+			}
+			if measure.GetDistance() <= maxMm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetAverageDistanceFromDirection calculates the average distance for a given direction.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction or the width is not valid.
+func GetAverageDistanceFromDirection(
+	measures *[360]*Measure,
+	width int,
+	direction CardinalDirection,
+) (float64, error) {
+	return averageDistanceFromDirection(measures[:], width, direction)
+}
+
+// getDirectionMiddleAngle rounds the angle of a direction to the middle angle of its window.
+//
+// Parameters:
+//
+// direction: The direction to get the middle angle for.
+//
+// Returns:
+//
+// The middle angle of the direction, or an error if the direction is not valid.
+func getDirectionMiddleAngle(direction CardinalDirection) (int, error) {
+	// Check if the direction is valid, otherwise it would be averaged as north
+	if !direction.IsValid() {
+		return 0, ErrInvalidDirection
+	}
+	directionAngle := direction.Angle()
+
+	// Round the angle
+	if directionAngle >= 180 {
+		directionAngle = math.Ceil(directionAngle)
+	} else {
+		directionAngle = math.Floor(directionAngle)
+	}
+	return int(directionAngle), nil
+}
+
+// averageDistanceFromDirection calculates the average distance for a given direction over a grid of measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction or the width is not valid.
+func averageDistanceFromDirection(
+	measures []*Measure,
+	width int,
+	direction CardinalDirection,
+) (float64, error) {
+	middleAngle, err := getDirectionMiddleAngle(direction)
+	if err != nil {
+		return 0, err
+	}
+
+	return averageDistanceFromAngle(
+		measures,
+		middleAngle,
+		width,
+	)
+}
+
+// GetMeasuresInDirection collects the valid measures within the window centered on a given direction.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// direction: The direction to center the window on.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The valid measures within the window sorted from its first angle to its last one, wrapping around the 0/360 seam, or
+// an error if the direction or the width is not valid.
+func GetMeasuresInDirection(
+	measures *[360]*Measure,
+	direction CardinalDirection,
+	width int,
+) ([]*Measure, error) {
+	return measuresInDirection(measures[:], direction, width)
+}
+
+// measuresInDirection collects the valid measures within the window centered on a given direction over a grid of
+// measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// direction: The direction to center the window on.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// The valid measures within the window sorted from its first angle to its last one, wrapping around the 0/360 seam, or
+// an error if the direction or the width is not valid.
+func measuresInDirection(
+	measures []*Measure,
+	direction CardinalDirection,
+	width int,
+) ([]*Measure, error) {
+	middleAngle, err := getDirectionMiddleAngle(direction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the range of angles to consider
+	angles, err := getAngleWindow(middleAngle, width)
+	if err != nil {
+		return nil, err
+	}
+	return getValidWindowMeasures(measures, angles), nil
+}
+
+// GetAverageDistancesFromDirections calculates the average distances for the specified directions.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// directions: The directions to calculate the average distances for.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction is not valid. The
+// directions without valid measures are omitted from the map.
+func GetAverageDistancesFromDirections(
+	measures *[360]*Measure,
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	return averageDistancesFromDirections(measures[:], width, directions...)
+}
+
+// averageDistancesFromDirections calculates the average distances for the specified directions over a grid of
+// measures.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// width: The sum of the angles to consider with both sides and the middle angle.
+// directions: The directions to calculate the average distances for.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction is not valid. The
+// directions without valid measures are omitted from the map.
+func averageDistancesFromDirections(
+	measures []*Measure,
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	// Accumulate the valid distances of each angle in a single pass
+	totals := newDegreeTotals(measures)
+
+	avgDistances := make(map[CardinalDirection]float64, len(directions))
+	for _, direction := range directions {
+		avgDistance, err := totals.averageDistanceFromDirection(width, direction)
+		if errors.Is(err, ErrNoValidMeasures) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		avgDistances[direction] = avgDistance
+	}
+	return avgDistances, nil
+}
+
+type (
+	// degreeTotals holds the sum and the count of the valid distances of each angle, so the average distance of many
+	// windows can be calculated without walking the measures again for each one.
+	degreeTotals struct {
+		distances [360]float64
+		counts    [360]int
+	}
+)
+
+// newDegreeTotals accumulates the valid distances of a grid of measures by angle, walking the grid once.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+//
+// Returns:
+//
+// A pointer to the degreeTotals of the grid.
+func newDegreeTotals(measures []*Measure) *degreeTotals {
+	bucketsPerDegree := len(measures) / 360
+	totals := &degreeTotals{}
+	for bucket, measure := range measures {
+		if measure == nil {
+			continue
+		}
+
+		// Check the distance and quality
+		if measure.GetDistance() == 0.0 || measure.GetQuality() == 0 {
+			continue
+		}
+		angle := bucket / bucketsPerDegree
+		totals.distances[angle] += measure.GetDistance()
+		totals.counts[angle]++
+	}
+	return totals
+}
+
+// averageDistanceFromDirection calculates the average distance for a given direction from the accumulated totals.
+//
+// Parameters:
+//
+// width: The sum of the angles to consider with both sides and the middle angle.
+// direction: The direction to calculate the average distance for.
+//
+// Returns:
+//
+// The average distance for the specified direction, or an error if the direction or the width is not valid, or if
+// there are no valid measures within the window.
+func (d *degreeTotals) averageDistanceFromDirection(width int, direction CardinalDirection) (float64, error) {
+	middleAngle, err := getDirectionMiddleAngle(direction)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check the window
+	if err = checkAngleWindow(middleAngle, width); err != nil {
+		return 0, err
+	}
+
+	// Sum the totals of the angles within the window
+	widthPerSide := (width - 1) / 2
+	var totalDistance float64
+	var count int
+	for offset := -widthPerSide; offset <= widthPerSide; offset++ {
+		angle := (middleAngle + offset + 360) % 360
+		totalDistance += d.distances[angle]
+		count += d.counts[angle]
+	}
+
+	// Check if there are valid distances
+	if count == 0 {
+		return 0, ErrNoValidMeasures
+	}
+	return totalDistance / float64(count), nil
+}
+
+// GetAverageDistancesWithWidths calculates the average distances for the specified directions, each one with its own
+// width.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// widths: A map with the directions as keys and the sum of the angles to consider around them as values.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction or width is not
+// valid. The directions without valid measures are omitted from the map.
+func GetAverageDistancesWithWidths(
+	measures *[360]*Measure,
+	widths map[CardinalDirection]int,
+) (map[CardinalDirection]float64, error) {
+	return averageDistancesWithWidths(measures[:], widths)
+}
+
+// averageDistancesWithWidths calculates the average distances for the specified directions over a grid of measures,
+// each one with its own width.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers with a multiple of 360 buckets, indexed by angle times the buckets per degree.
+// widths: A map with the directions as keys and the sum of the angles to consider around them as values.
+//
+// Returns:
+//
+// A map with directions as keys and their average distances as values, or an error if any direction or width is not
+// valid. The directions without valid measures are omitted from the map.
+func averageDistancesWithWidths(
+	measures []*Measure,
+	widths map[CardinalDirection]int,
+) (map[CardinalDirection]float64, error) {
+	// Accumulate the valid distances of each angle in a single pass
+	totals := newDegreeTotals(measures)
+
+	avgDistances := make(map[CardinalDirection]float64, len(widths))
+	for direction, width := range widths {
+		avgDistance, err := totals.averageDistanceFromDirection(width, direction)
+		if errors.Is(err, ErrNoValidMeasures) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", direction, err)
+		}
+		avgDistances[direction] = avgDistance
+	}
+	return avgDistances, nil
+}
+
+// GetAverageDistancesFromAllDirections calculates the average distance for all cardinal directions, matching the
+// spelling of the Handler method.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not
+// valid. The directions without valid measures are omitted from the map.
+func GetAverageDistancesFromAllDirections(
+	measures *[360]*Measure,
+	width int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromDirections(
+		measures,
+		width,
+		CardinalDirections...,
+	)
+}
+
+// GetAverageDistanceFromAllDirections calculates the average distance for all cardinal directions.
+//
+// Deprecated: Use GetAverageDistancesFromAllDirections instead.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with all cardinal directions as keys and their average distances as values, or an error if any direction is not
+// valid. The directions without valid measures are omitted from the map.
+func GetAverageDistanceFromAllDirections(
+	measures *[360]*Measure,
+	width int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromAllDirections(measures, width)
+}
+
+// GetAverageDistancesFromPrimaryDirections calculates the average distance for the 8 primary cardinal directions, unlike
+// GetAverageDistancesFromAllDirections which covers the 16 directions of CardinalDirections.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// width: The sum of the angles to consider with both sides and the middle angle.
+//
+// Returns:
+//
+// A map with the primary cardinal directions as keys and their average distances as values, or an error if the width is
+// not valid. The directions without valid measures are omitted from the map.
+func GetAverageDistancesFromPrimaryDirections(
+	measures *[360]*Measure,
+	width int,
+) (map[CardinalDirection]float64, error) {
+	return GetAverageDistancesFromDirections(
+		measures,
+		width,
+		PrimaryCardinalDirections...,
+	)
+}
+
+// ConvertDistances converts the average distances in millimeters returned by the direction helpers, such as
+// GetAverageDistancesFromAllDirections, to the given unit.
+//
+// Parameters:
+//
+// distances: A map with directions as keys and their distances in millimeters as values.
+// unit: The unit to convert the distances to.
+//
+// Returns:
+//
+// A new map with the same directions as keys and their distances in the given unit as values, or in millimeters if the
+// unit is not valid.
+func ConvertDistances(
+	distances map[CardinalDirection]float64,
+	unit DistanceUnit,
+) map[CardinalDirection]float64 {
+	convertedDistances := make(map[CardinalDirection]float64, len(distances))
+	for direction, distance := range distances {
+		convertedDistances[direction] = unit.FromMillimeters(distance)
+	}
+	return convertedDistances
+}
+
+// GetValidMeasures collects the valid measures, skipping the nil, zero and out of range entries.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+//
+// Returns:
+//
+// The valid measures sorted by angle.
+func GetValidMeasures(measures *[360]*Measure, maxDistanceLimit float64) []*Measure {
+	validMeasures := make([]*Measure, 0, len(measures))
+	for _, measure := range measures {
+		if isValidMeasure(measure, maxDistanceLimit) {
+			validMeasures = append(validMeasures, measure)
+		}
+	}
+	return validMeasures
+}
+
+// GetPointCloud converts the given measures to Cartesian points, skipping the nil entries and the measures with a zero
+// distance or quality, which would be drawn at the origin.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func GetPointCloud(measures *[360]*Measure) [][2]float64 {
+	return pointCloud(measures[:], math.Inf(1))
+}
+
+// pointCloud converts the valid measures of the given grid to Cartesian points.
+//
+// Parameters:
+//
+// measures: A grid of Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func pointCloud(measures []*Measure, maxDistanceLimit float64) [][2]float64 {
+	points := make([][2]float64, 0, len(measures))
+	for _, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+		x, y := measure.ToCartesian()
+		points = append(points, [2]float64{x, y})
+	}
+	return points
+}
+
+// handlerPointCloud converts the current scan of the given handler to Cartesian points, using its own point cloud if it
+// implements the PointCloudProvider interface, so its maximum distance limit is applied.
+//
+// Parameters:
+//
+// handler: The RPLiDAR handler.
+//
+// Returns:
+//
+// A slice of (x, y) points in millimeters.
+func handlerPointCloud(handler Handler) [][2]float64 {
+	// Check if the handler can build its own point cloud
+	if provider, ok := handler.(PointCloudProvider); ok {
+		return provider.GetPointCloud()
+	}
+	return GetPointCloud(handler.GetMeasures())
+}
+
+// GetNearestObstacle finds the closest valid measure.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+//
+// Returns:
+//
+// The angle and distance of the closest valid measure, and false if there are no valid measures.
+func GetNearestObstacle(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+) (angle int, distance float64, ok bool) {
+	for index, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+		if !ok || measure.GetDistance() < distance {
+			angle = index
+			distance = measure.GetDistance()
+			ok = true
+		}
+	}
+	return angle, distance, ok
+}
+
+// newScanResult creates a ScanResult computing the metadata of the given measures.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+// frequency: The scan frequency in Hz at the time of the capture.
+// timestamp: The time at which the measures were captured.
+//
+// Returns:
+//
+// The scan result of the given measures.
+func newScanResult(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	frequency float64,
+	timestamp time.Time,
+) ScanResult {
+	scanResult := ScanResult{
+		Measures:  *measures,
+		Timestamp: timestamp,
+		Coverage:  CoverageRatio(measures, maxDistanceLimit),
+		Frequency: frequency,
+	}
+
+	// Check if there's a valid measure to report the nearest obstacle
+	if angle, distance, ok := GetNearestObstacle(measures, maxDistanceLimit); ok {
+		scanResult.NearestObstacle = &Obstacle{Angle: angle, Distance: distance}
+	}
+	return scanResult
+}
+
+// DetectMotion compares two scans and reports the angles where the distance changed by more than the threshold. The
+// angles without a valid measure on either scan are skipped.
+//
+// Parameters:
+//
+// previous: A pointer to an array of 360 Measure pointers indexed by angle, of the previous scan.
+// current: A pointer to an array of 360 Measure pointers indexed by angle, of the current scan.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+// distThresholdMm: The minimum distance change in millimeters to report.
+//
+// Returns:
+//
+// The motion events sorted by angle, or nil if the threshold is not greater than zero.
+func DetectMotion(
+	previous *[360]*Measure,
+	current *[360]*Measure,
+	maxDistanceLimit float64,
+	distThresholdMm float64,
+) []MotionEvent {
+	// Check the threshold
+	if distThresholdMm <= 0 {
+		return nil
+	}
+
+	var motionEvents []MotionEvent
+	for angle := range current {
+		// Check if both scans have a valid measure at the angle
+		if !isValidMeasure(previous[angle], maxDistanceLimit) || !isValidMeasure(current[angle], maxDistanceLimit) {
+			continue
+		}
+
+		// Check if the distance changed by more than the threshold
+		previousDistance := previous[angle].GetDistance()
+		currentDistance := current[angle].GetDistance()
+		if math.Abs(currentDistance-previousDistance) <= distThresholdMm {
+			continue
+		}
+
+		kind := MotionKindReceding
+		if currentDistance < previousDistance {
+			kind = MotionKindApproaching
+		}
+		motionEvents = append(
+			motionEvents, MotionEvent{
+				Angle:            angle,
+				PreviousDistance: previousDistance,
+				CurrentDistance:  currentDistance,
+				Kind:             kind,
+			},
+		)
+	}
+	return motionEvents
+}
+
+// GetFarthestValidDistance finds the valid measure with the most clearance.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+// maxDistanceLimit: Maximum distance limit for valid measures.
+//
+// Returns:
+//
+// The angle and distance of the farthest valid measure, and false if there are no valid measures.
+func GetFarthestValidDistance(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+) (angle int, distance float64, ok bool) {
+	for index, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+		if !ok || measure.GetDistance() > distance {
+			angle = index
+			distance = measure.GetDistance()
+			ok = true
+		}
+	}
+	return angle, distance, ok
+}
+
+// DetectClusters groups the contiguous valid measures with similar distances. A new cluster is started when the angular
+// gap to the previous valid measure exceeds maxGapDeg or the distance jumps more than maxDistJumpMm. The clusters at
+// both sides of the 0/360 seam are merged if they meet the same conditions, and the merged cluster, whose start angle is
+// greater than its end angle, is placed last.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// maxGapDeg: The maximum angular gap in degrees between two measures of the same cluster.
+// maxDistJumpMm: The maximum distance jump in millimeters between two measures of the same cluster.
+//
+// Returns:
+//
+// The clusters sorted by their start angle.
+func DetectClusters(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	maxGapDeg int,
+	maxDistJumpMm float64,
+) []Cluster {
+	var clusters []Cluster
+	previousAngle := -1
+	for angle, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+		distance := measure.GetDistance()
+
+		// Check if the measure continues the current cluster
+		if previousAngle >= 0 && angle-previousAngle <= maxGapDeg {
+			current := &clusters[len(clusters)-1]
+			if math.Abs(distance-current.lastDistance) <= maxDistJumpMm {
+				current.EndAngle = angle
+				current.MeanDistance += distance
+				current.MinDistance = min(current.MinDistance, distance)
+				current.lastDistance = distance
+				current.count++
+				previousAngle = angle
+				continue
+			}
+		}
+
+		// Start a new cluster
+		clusters = append(
+			clusters, Cluster{
+				StartAngle:    angle,
+				EndAngle:      angle,
+				MeanDistance:  distance,
+				MinDistance:   distance,
+				count:         1,
+				firstDistance: distance,
+				lastDistance:  distance,
+			},
+		)
+		previousAngle = angle
+	}
+
+	// Check if the last cluster continues the first one across the 0/360 seam
+	if len(clusters) > 1 {
+		first := clusters[0]
+		last := &clusters[len(clusters)-1]
+		if first.StartAngle+360-last.EndAngle <= maxGapDeg &&
+			math.Abs(first.firstDistance-last.lastDistance) <= maxDistJumpMm {
+			// Extend the last cluster with the first one, so the clusters stay sorted by their start angle
+			last.EndAngle = first.EndAngle
+			last.MeanDistance += first.MeanDistance
+			last.MinDistance = min(last.MinDistance, first.MinDistance)
+			last.lastDistance = first.lastDistance
+			last.count += first.count
+			clusters = clusters[1:]
+		}
+	}
+
+	// Turn the accumulated distances into means
+	for i := range clusters {
+		clusters[i].MeanDistance /= float64(clusters[i].count)
+	}
+	return clusters
+}
+
+// isBlocked checks if the given measure is a valid return closer than the clearance.
+//
+// Parameters:
+//
+// measure: The measure to check.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// clearanceMm: The clearance in millimeters.
+//
+// Returns:
+//
+// True if the measure blocks the path, false otherwise.
+func isBlocked(measure *Measure, maxDistanceLimit float64, clearanceMm float64) bool {
+	return isValidMeasure(measure, maxDistanceLimit) && measure.GetDistance() < clearanceMm
+}
+
+// FreeCorridorWidth expands from the heading to both sides until it hits a measure closer than the clearance, handling
+// the 0/360 seam. Angles without a valid measure are considered clear.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// heading: The heading angle in degrees.
+// clearanceMm: The clearance in millimeters.
+//
+// Returns:
+//
+// The last clear angles at the left (counterclockwise) and at the right (clockwise) of the heading, or NoCorridorAngle
+// at both sides if the heading is not between 0 and 359, if the clearance is not greater than zero, or if the heading
+// itself is blocked.
+func FreeCorridorWidth(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	heading int,
+	clearanceMm float64,
+) (leftAngle, rightAngle int) {
+	// Check if the heading and the clearance are valid
+	if heading < 0 || heading >= 360 || clearanceMm <= 0 {
+		return NoCorridorAngle, NoCorridorAngle
+	}
+
+	// Check if the heading is blocked
+	if isBlocked(measures[heading], maxDistanceLimit, clearanceMm) {
+		return NoCorridorAngle, NoCorridorAngle
+	}
+
+	// Expand to each side up to the opposite angle
+	leftOffset, rightOffset := 0, 0
+	for leftOffset < 180 && !isBlocked(
+		measures[(heading-leftOffset-1+360)%360],
+		maxDistanceLimit,
+		clearanceMm,
+	) {
+		leftOffset++
+	}
+	for rightOffset < 180 && !isBlocked(
+		measures[(heading+rightOffset+1)%360],
+		maxDistanceLimit,
+		clearanceMm,
+	) {
+		rightOffset++
+	}
+	return (heading - leftOffset + 360) % 360, (heading + rightOffset) % 360
+}
+
+// GetInterpolatedScan fills the angles without a valid measure by linearly interpolating the distances of the nearest
+// valid measures at both sides, if the gap between them is at most maxGap degrees. The gaps wrap around the 0/360 seam,
+// and the interpolated measures are flagged, taking the lowest quality and the oldest timestamp of their neighbors.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// maxGap: The maximum number of consecutive missing angles to fill.
+//
+// Returns:
+//
+// A copy of the measures with the small gaps filled.
+func GetInterpolatedScan(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	maxGap int,
+) *[360]*Measure {
+	interpolated := *measures
+
+	// Collect the angles with valid measures
+	var validAngles []int
+	for angle, measure := range measures {
+		if isValidMeasure(measure, maxDistanceLimit) {
+			validAngles = append(validAngles, angle)
+		}
+	}
+
+	// Check if there are enough valid measures to interpolate
+	if len(validAngles) < 2 {
+		return &interpolated
+	}
+
+	for index, startAngle := range validAngles {
+		endAngle := validAngles[(index+1)%len(validAngles)]
+		if endAngle <= startAngle {
+			endAngle += 360
+		}
+
+		// Check if the gap is small enough to be filled
+		gap := endAngle - startAngle - 1
+		if gap == 0 || gap > maxGap {
+			continue
+		}
+
+		start := measures[startAngle]
+		end := measures[endAngle%360]
+		quality := min(start.GetQuality(), end.GetQuality())
+		timestamp := start.GetTimestamp()
+		if end.GetTimestamp().Before(timestamp) {
+			timestamp = end.GetTimestamp()
+		}
+		for angle := startAngle + 1; angle < endAngle; angle++ {
+			ratio := float64(angle-startAngle) / float64(endAngle-startAngle)
+			interpolated[angle%360] = &Measure{
+				angle:          float64(angle % 360),
+				rawAngle:       float64(angle % 360),
+				distance:       start.GetDistance() + (end.GetDistance()-start.GetDistance())*ratio,
+				quality:        quality,
+				timestamp:      timestamp,
+				isInterpolated: true,
+			}
+		}
+	}
+	return &interpolated
+}
+
+// CoverageRatio calculates the fraction of the 360 angles that have a valid measure.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+//
+// Returns:
+//
+// The fraction of angles with a valid measure, in [0, 1].
+func CoverageRatio(measures *[360]*Measure, maxDistanceLimit float64) float64 {
+	validMeasures := 0
+	for _, measure := range measures {
+		if isValidMeasure(measure, maxDistanceLimit) {
+			validMeasures++
+		}
+	}
+	return float64(validMeasures) / float64(len(measures))
+}
+
+// CalibrateAngleOffset finds the strongest return, the one with the highest quality, within the search window around the
+// actual angle of a known reference object, and calculates the offset that aligns it with that angle. The ties are
+// broken by the return closest to the actual angle.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// knownObjectActualAngle: The actual angle in degrees of the reference object.
+// searchWidth: The sum of the angles to search with both sides and the actual angle.
+//
+// Returns:
+//
+// The offset in degrees within (-180, 180] to add to the angle adjustment, or an error if the angle or the width is not
+// valid, or if there are no valid measures within the search window.
+func CalibrateAngleOffset(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	knownObjectActualAngle int,
+	searchWidth int,
+) (float64, error) {
+	// Calculate the range of angles to search
+	angles, err := getAngleWindow(knownObjectActualAngle, searchWidth)
+	if err != nil {
+		return 0, err
+	}
+
+	// Find the strongest return, the angles are sorted from the left side to the right side of the window
+	var strongest *Measure
+	strongestOffset := 0
+	for _, angle := range angles {
+		measure := measures[angle]
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+
+		offset := knownObjectActualAngle - angle
+		if offset > 180 {
+			offset -= 360
+		} else if offset < -180 {
+			offset += 360
+		}
+		isStronger := strongest == nil || measure.GetQuality() > strongest.GetQuality()
+		isCloser := strongest != nil && measure.GetQuality() == strongest.GetQuality() && abs(offset) < abs(strongestOffset)
+		if isStronger || isCloser {
+			strongest = measure
+			strongestOffset = offset
+		}
+	}
+
+	// Check if a return was found
+	if strongest == nil {
+		return 0, ErrNoValidMeasures
+	}
+
+	// Calculate the offset from the exact angle of the return
+	offset := normalizeAngle(float64(knownObjectActualAngle) - strongest.GetAngle())
+	if offset > 180 {
+		offset -= 360
+	}
+	return offset, nil
+}
+
+// abs returns the absolute value of an integer.
+//
+// Parameters:
+//
+// value: The integer.
+//
+// Returns:
+//
+// The absolute value of the integer.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// GetDownsampledScan reduces the measures to one representative per sector of step degrees, which is the nearest valid
+// measure of the sector. If 360 isn't a multiple of the step, the last sector contains the leftover angles.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// step: The width of each sector in degrees.
+//
+// Returns:
+//
+// A slice with the representative of each sector, which is nil if the sector has no valid measures, or an error if the
+// step is not valid.
+func GetDownsampledScan(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	step int,
+) ([]*Measure, error) {
+	// Check if the step is valid
+	if step < 1 || step > 360 {
+		return nil, ErrInvalidDownsampleStep
+	}
+
+	downsampled := make([]*Measure, (360+step-1)/step)
+	for angle, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) {
+			continue
+		}
+
+		// Keep the nearest measure of the sector
+		sector := angle / step
+		if downsampled[sector] == nil || measure.GetDistance() < downsampled[sector].GetDistance() {
+			downsampled[sector] = measure
+		}
+	}
+	return downsampled, nil
+}
+
+// GetPolarHistogram calculates the obstacle density of each sector of sectorDeg degrees, as used by the Vector Field
+// Histogram navigation. Each valid measure closer than the threshold adds its closeness, (threshold - distance) /
+// threshold, to the density of its sector. Sector i covers the angles [i*sectorDeg, (i+1)*sectorDeg), so the first
+// sector starts at 0 degrees and no sector crosses the 0/360 seam, and if 360 isn't a multiple of sectorDeg the last
+// sector contains the leftover angles.
+//
+// Parameters:
+//
+// measures: A pointer to an array of 360 Measure pointers.
+// maxDistanceLimit: Maximum distance limit for valid measurements.
+// sectorDeg: The width of each sector in degrees.
+// thresholdMm: The distance in millimeters under which a measure is considered an obstacle.
+//
+// Returns:
+//
+// The obstacle density of each sector in clockwise order, or an error if the sector width or the threshold is not
+// valid.
+func GetPolarHistogram(
+	measures *[360]*Measure,
+	maxDistanceLimit float64,
+	sectorDeg int,
+	thresholdMm float64,
+) ([]float64, error) {
+	// Check if the sector width is valid
+	if sectorDeg < 1 || sectorDeg > 360 {
+		return nil, ErrInvalidSectorWidth
+	}
+
+	// Check if the threshold is valid
+	if thresholdMm <= 0 {
+		return nil, ErrInvalidThreshold
+	}
+
+	histogram := make([]float64, (360+sectorDeg-1)/sectorDeg)
+	for angle, measure := range measures {
+		if !isValidMeasure(measure, maxDistanceLimit) || measure.GetDistance() >= thresholdMm {
+			continue
+		}
+		histogram[angle/sectorDeg] += (thresholdMm - measure.GetDistance()) / thresholdMm
+	}
+	return histogram, nil
+}
+
+// WriteScanCSV writes the given measures as CSV with an angle, distance and quality header, one row per non-nil measure
+// sorted by angle.
+//
+// Parameters:
+//
+// w: The writer to write the CSV to.
+// measures: A pointer to an array of 360 Measure pointers indexed by angle.
+//
+// Returns:
+//
+// An error if the CSV couldn't be written.
+func WriteScanCSV(w io.Writer, measures *[360]*Measure) error {
+	// Collect the non-nil measures sorted by angle
+	rows := make([]*Measure, 0, len(measures))
+	for _, measure := range measures {
+		if measure != nil {
+			rows = append(rows, measure)
+		}
+	}
+	sort.Slice(
+		rows, func(i, j int) bool {
+			return rows[i].GetAngle() < rows[j].GetAngle()
+		},
+	)
+
+	// Write the header and the rows
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(ScanCSVHeader); err != nil {
+		return err
+	}
+	for _, measure := range rows {
+		if err := csvWriter.Write(
+			[]string{
+				strconv.FormatFloat(measure.GetAngle(), 'f', -1, 64),
+				strconv.FormatFloat(measure.GetDistance(), 'f', -1, 64),
+				strconv.Itoa(measure.GetQuality()),
+			},
+		); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
 }
@@ -0,0 +1,581 @@
+package go_rplidar_sdk_handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestGetAngleWindow checks the angles of the windows, including the ones crossing the 0/360 seam.
+func TestGetAngleWindow(t *testing.T) {
+	tests := []struct {
+		name           string
+		middleAngle    int
+		width          int
+		expectedAngles []int
+	}{
+		{name: "single angle", middleAngle: 90, width: 1, expectedAngles: []int{90}},
+		{name: "middle", middleAngle: 90, width: 5, expectedAngles: []int{88, 89, 90, 91, 92}},
+		{name: "middle angle 0", middleAngle: 0, width: 5, expectedAngles: []int{358, 359, 0, 1, 2}},
+		{name: "middle angle 359", middleAngle: 359, width: 5, expectedAngles: []int{357, 358, 359, 0, 1}},
+		{name: "crossing the seam", middleAngle: 2, width: 7, expectedAngles: []int{359, 0, 1, 2, 3, 4, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			angles, err := getAngleWindow(test.middleAngle, test.width)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(angles, test.expectedAngles) {
+				t.Errorf("expected angles %v, got %v", test.expectedAngles, angles)
+			}
+		})
+	}
+}
+
+// TestGetAngleWindowBounds checks that every valid window has deduplicated angles within [0, 360).
+func TestGetAngleWindowBounds(t *testing.T) {
+	for _, middleAngle := range []int{0, 1, 179, 358, 359} {
+		for _, width := range []int{1, 3, 91, 357, 359} {
+			angles, err := getAngleWindow(middleAngle, width)
+			if err != nil {
+				t.Fatalf("unexpected error for middle angle %d and width %d: %v", middleAngle, width, err)
+			}
+
+			// Check the number of angles
+			if len(angles) != width {
+				t.Errorf("expected %d angles for middle angle %d, got %d", width, middleAngle, len(angles))
+			}
+
+			// Check the angles are within range and deduplicated
+			seen := make(map[int]struct{}, len(angles))
+			for _, angle := range angles {
+				if angle < 0 || angle >= 360 {
+					t.Errorf("angle %d of the window of %d is not within [0, 360)", angle, middleAngle)
+				}
+				if _, ok := seen[angle]; ok {
+					t.Errorf("angle %d of the window of %d is duplicated", angle, middleAngle)
+				}
+				seen[angle] = struct{}{}
+			}
+		}
+	}
+}
+
+// TestGetAngleWindowErrors checks the errors of the invalid middle angles and widths.
+func TestGetAngleWindowErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		middleAngle   int
+		width         int
+		expectedError error
+	}{
+		{name: "negative middle angle", middleAngle: -1, width: 1, expectedError: ErrInvalidAngle},
+		{name: "middle angle 360", middleAngle: 360, width: 1, expectedError: ErrInvalidAngle},
+		{name: "even width", middleAngle: 0, width: 4, expectedError: ErrAngleWidthMustBeOdd},
+		{name: "zero width", middleAngle: 0, width: 0, expectedError: ErrAngleWidthMustBeOdd},
+		{name: "negative width", middleAngle: 0, width: -1, expectedError: ErrAngleWidthTooSmall},
+		{name: "width 361", middleAngle: 0, width: 361, expectedError: ErrAngleWidthTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := getAngleWindow(test.middleAngle, test.width); !errors.Is(err, test.expectedError) {
+				t.Errorf("expected %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+// newTestGrid creates a grid of measures with the given buckets per degree, with some empty, zero distance and zero
+// quality buckets.
+func newTestGrid(bucketsPerDegree int) []*Measure {
+	measures := make([]*Measure, 360*bucketsPerDegree)
+	for bucket := range measures {
+		switch {
+		case bucket%7 == 0:
+			continue
+		case bucket%11 == 0:
+			measures[bucket] = &Measure{angle: float64(bucket) / float64(bucketsPerDegree), quality: 10}
+		case bucket%13 == 0:
+			measures[bucket] = &Measure{angle: float64(bucket) / float64(bucketsPerDegree), distance: 100}
+		default:
+			measures[bucket] = &Measure{
+				angle:    float64(bucket) / float64(bucketsPerDegree),
+				distance: 500 + float64(bucket%97)*3.7,
+				quality:  10 + bucket%40,
+			}
+		}
+	}
+
+	// Leave a gap without valid measures around the north direction
+	for bucket := 0; bucket < 3*bucketsPerDegree; bucket++ {
+		measures[bucket] = nil
+		measures[len(measures)-1-bucket] = nil
+	}
+	return measures
+}
+
+// perWindowAverageDistances calculates the average distances of the directions one window at a time.
+func perWindowAverageDistances(
+	measures []*Measure,
+	width int,
+	directions ...CardinalDirection,
+) (map[CardinalDirection]float64, error) {
+	avgDistances := make(map[CardinalDirection]float64)
+	for _, direction := range directions {
+		avgDistance, err := averageDistanceFromDirection(measures, width, direction)
+		if errors.Is(err, ErrNoValidMeasures) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		avgDistances[direction] = avgDistance
+	}
+	return avgDistances, nil
+}
+
+// TestAverageDistancesFromDirectionsSinglePass checks that the single pass averages match the per window averages.
+func TestAverageDistancesFromDirectionsSinglePass(t *testing.T) {
+	for _, bucketsPerDegree := range []int{1, 2, 10} {
+		measures := newTestGrid(bucketsPerDegree)
+		for _, width := range []int{1, 5, 15, 45, 359} {
+			t.Run(fmt.Sprintf("%d buckets per degree and width %d", bucketsPerDegree, width), func(t *testing.T) {
+				expected, err := perWindowAverageDistances(measures, width, CardinalDirections...)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				avgDistances, err := averageDistancesFromDirections(measures, width, CardinalDirections...)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				// Check both have the same directions and averages
+				if len(avgDistances) != len(expected) {
+					t.Fatalf("expected %d directions, got %d", len(expected), len(avgDistances))
+				}
+				for direction, expectedDistance := range expected {
+					avgDistance, ok := avgDistances[direction]
+					if !ok {
+						t.Fatalf("missing the %s direction", direction)
+					}
+					if math.Abs(avgDistance-expectedDistance) > 1e-9*expectedDistance {
+						t.Errorf("expected %f for the %s direction, got %f", expectedDistance, direction, avgDistance)
+					}
+				}
+
+				// Check the widths variant
+				widths := make(map[CardinalDirection]int, len(CardinalDirections))
+				for _, direction := range CardinalDirections {
+					widths[direction] = width
+				}
+				widthsDistances, err := averageDistancesWithWidths(measures, widths)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for direction, avgDistance := range avgDistances {
+					if widthsDistances[direction] != avgDistance {
+						t.Errorf(
+							"expected %f for the %s direction, got %f",
+							avgDistance,
+							direction,
+							widthsDistances[direction],
+						)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestAverageDistancesFromDirectionsErrors checks that the single pass averages return the per window errors.
+func TestAverageDistancesFromDirectionsErrors(t *testing.T) {
+	measures := newTestGrid(1)
+	tests := []struct {
+		name          string
+		width         int
+		directions    []CardinalDirection
+		expectedError error
+	}{
+		{
+			name:          "invalid direction",
+			width:         5,
+			directions:    []CardinalDirection{CardinalDirectionNil},
+			expectedError: ErrInvalidDirection,
+		},
+		{name: "even width", width: 4, directions: CardinalDirections, expectedError: ErrAngleWidthMustBeOdd},
+		{name: "negative width", width: -1, directions: CardinalDirections, expectedError: ErrAngleWidthTooSmall},
+		{name: "width 361", width: 361, directions: CardinalDirections, expectedError: ErrAngleWidthTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := perWindowAverageDistances(measures, test.width, test.directions...)
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected the per window error %v, got %v", test.expectedError, err)
+			}
+			_, err = averageDistancesFromDirections(measures, test.width, test.directions...)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("expected %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+// BenchmarkAverageDistancesFromDirections benchmarks the single pass averages of all the directions against the per
+// window averages.
+func BenchmarkAverageDistancesFromDirections(b *testing.B) {
+	measures := newTestGrid(1)
+	benchmarks := []struct {
+		name            string
+		averageDistance func(
+			measures []*Measure,
+			width int,
+			directions ...CardinalDirection,
+		) (map[CardinalDirection]float64, error)
+	}{
+		{name: "single pass", averageDistance: averageDistancesFromDirections},
+		{name: "per window", averageDistance: perWindowAverageDistances},
+	}
+
+	for _, benchmark := range benchmarks {
+		for _, width := range []int{15, 45} {
+			b.Run(fmt.Sprintf("%s with width %d", benchmark.name, width), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := benchmark.averageDistance(measures, width, CardinalDirections...); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestGetPointCloudSkipsInvalidMeasures checks that the point clouds skip the measures without a valid return, which
+// would be drawn at the origin.
+func TestGetPointCloudSkipsInvalidMeasures(t *testing.T) {
+	var measures [360]*Measure
+	measures[0] = &Measure{angle: 0, distance: 1000, quality: 47}
+	measures[90] = &Measure{angle: 90, distance: 2000, quality: 47}
+	measures[180] = &Measure{angle: 180, distance: 0, quality: 47}
+	measures[270] = &Measure{angle: 270, distance: 1000, quality: 0}
+	measures[300] = &Measure{angle: 300, distance: 9000, quality: 47}
+
+	tests := []struct {
+		name           string
+		pointCloud     func() [][2]float64
+		expectedPoints int
+	}{
+		{
+			name: "GetPointCloud",
+			pointCloud: func() [][2]float64 {
+				return GetPointCloud(&measures)
+			},
+			expectedPoints: 3,
+		},
+		{
+			name: "Scan.PointCloud within the max distance limit",
+			pointCloud: func() [][2]float64 {
+				scan, err := NewScan(&measures, 5000)
+				if err != nil {
+					t.Fatalf("failed to create the scan: %v", err)
+				}
+				return scan.PointCloud()
+			},
+			expectedPoints: 2,
+		},
+		{
+			name: "MockHandler.GetPointCloud within the max distance limit",
+			pointCloud: func() [][2]float64 {
+				mock, err := NewMockHandler(5000, 1)
+				if err != nil {
+					t.Fatalf("failed to create the mock: %v", err)
+				}
+				mock.SetMeasures(&measures)
+				return mock.GetPointCloud()
+			},
+			expectedPoints: 2,
+		},
+		{
+			name: "handlerPointCloud with a PointCloudProvider",
+			pointCloud: func() [][2]float64 {
+				mock, err := NewMockHandler(5000, 1)
+				if err != nil {
+					t.Fatalf("failed to create the mock: %v", err)
+				}
+				mock.SetMeasures(&measures)
+				return handlerPointCloud(mock)
+			},
+			expectedPoints: 2,
+		},
+		{
+			name: "handlerPointCloud without optional interfaces",
+			pointCloud: func() [][2]float64 {
+				mock, err := NewMockHandler(5000, 1)
+				if err != nil {
+					t.Fatalf("failed to create the mock: %v", err)
+				}
+				mock.SetMeasures(&measures)
+
+				// Hide the optional interfaces of the mock behind the bare Handler interface
+				return handlerPointCloud(struct{ Handler }{mock})
+			},
+			expectedPoints: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			points := test.pointCloud()
+			if len(points) != test.expectedPoints {
+				t.Fatalf("expected %d points, got %d: %v", test.expectedPoints, len(points), points)
+			}
+			for _, point := range points {
+				if point == [2]float64{} {
+					t.Errorf("unexpected point at the origin: %v", points)
+				}
+			}
+		})
+	}
+}
+
+// TestHandlerGetPointCloudSkipsInvalidMeasures checks that the handler point clouds skip the stored measures with a zero
+// distance or quality.
+func TestHandlerGetPointCloudSkipsInvalidMeasures(t *testing.T) {
+	h := newTestLineHandler(t)
+	for _, line := range []string{"0.00 1000.00 47", "90.00 0.00 47", "180.00 1000.00 0", "270.00 2000.00 47"} {
+		if err := h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+
+	for name, points := range map[string][][2]float64{
+		"GetPointCloud":           h.GetPointCloud(),
+		"GetPointCloudRobotFrame": h.GetPointCloudRobotFrame(),
+	} {
+		if len(points) != 2 {
+			t.Errorf("expected 2 points from %s, got %d: %v", name, len(points), points)
+		}
+		for _, point := range points {
+			if point == [2]float64{} {
+				t.Errorf("unexpected point at the origin from %s: %v", name, points)
+			}
+		}
+	}
+}
+
+// TestFreeCorridorWidth checks the clear angles at both sides of the heading, including across the 0/360 seam, and the
+// NoCorridorAngle results.
+func TestFreeCorridorWidth(t *testing.T) {
+	var measures [360]*Measure
+	for _, angle := range []int{20, 340, 90} {
+		measures[angle] = &Measure{angle: float64(angle), distance: 300, quality: 47}
+	}
+	measures[10] = &Measure{angle: 10, distance: 2000, quality: 47}
+
+	tests := []struct {
+		name          string
+		heading       int
+		clearanceMm   float64
+		expectedLeft  int
+		expectedRight int
+	}{
+		{name: "across the seam", heading: 0, clearanceMm: 500, expectedLeft: 341, expectedRight: 19},
+		{name: "far measures are clear", heading: 10, clearanceMm: 500, expectedLeft: 341, expectedRight: 19},
+		{name: "between two obstacles", heading: 50, clearanceMm: 500, expectedLeft: 21, expectedRight: 89},
+		{name: "short clearance", heading: 0, clearanceMm: 200, expectedLeft: 180, expectedRight: 180},
+		{name: "blocked heading", heading: 20, clearanceMm: 500, expectedLeft: NoCorridorAngle, expectedRight: NoCorridorAngle},
+		{name: "negative heading", heading: -1, clearanceMm: 500, expectedLeft: NoCorridorAngle, expectedRight: NoCorridorAngle},
+		{name: "heading out of range", heading: 360, clearanceMm: 500, expectedLeft: NoCorridorAngle, expectedRight: NoCorridorAngle},
+		{name: "zero clearance", heading: 0, clearanceMm: 0, expectedLeft: NoCorridorAngle, expectedRight: NoCorridorAngle},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			left, right := FreeCorridorWidth(&measures, 5000, test.heading, test.clearanceMm)
+			if left != test.expectedLeft || right != test.expectedRight {
+				t.Errorf(
+					"expected the corridor (%d, %d), got (%d, %d)",
+					test.expectedLeft,
+					test.expectedRight,
+					left,
+					right,
+				)
+			}
+		})
+	}
+}
+
+// TestAverageDistancesOmitDirectionsWithoutMeasures checks the comma-ok contract of the maps of average distances: the
+// directions without valid measures are missing from the map instead of being reported as a zero distance.
+func TestAverageDistancesOmitDirectionsWithoutMeasures(t *testing.T) {
+	// Store valid measures only around the north
+	var measures [360]*Measure
+	lines := make([]string, 0, 11)
+	for offset := -5; offset <= 5; offset++ {
+		angle := (offset + 360) % 360
+		measures[angle] = &Measure{angle: float64(angle), distance: 1000, quality: 47}
+		lines = append(lines, fmt.Sprintf("%d.00 1000.00 47", angle))
+	}
+
+	mock, err := NewMockHandler(5000, 1)
+	if err != nil {
+		t.Fatalf("failed to create the mock: %v", err)
+	}
+	mock.SetMeasures(&measures)
+	scan, err := NewScan(&measures, 5000)
+	if err != nil {
+		t.Fatalf("failed to create the scan: %v", err)
+	}
+	h := newTestLineHandler(t)
+	for _, line := range lines {
+		if err = h.handleStdoutLine(line); err != nil {
+			t.Fatalf("failed to handle the line %q: %v", line, err)
+		}
+	}
+
+	directions := []CardinalDirection{CardinalDirectionNorth, CardinalDirectionEast}
+	widths := map[CardinalDirection]int{CardinalDirectionNorth: 5, CardinalDirectionEast: 5}
+	tests := []struct {
+		name         string
+		avgDistances func() (map[CardinalDirection]float64, error)
+	}{
+		{
+			name: "GetAverageDistancesFromDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return GetAverageDistancesFromDirections(&measures, 5, directions...)
+			},
+		},
+		{
+			name: "GetAverageDistancesFromAllDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return GetAverageDistancesFromAllDirections(&measures, 5)
+			},
+		},
+		{
+			name: "GetAverageDistancesFromPrimaryDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return GetAverageDistancesFromPrimaryDirections(&measures, 5)
+			},
+		},
+		{
+			name: "GetAverageDistancesWithWidths",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return GetAverageDistancesWithWidths(&measures, widths)
+			},
+		},
+		{
+			name: "DefaultHandler.GetAverageDistancesFromDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return h.GetAverageDistancesFromDirections(5, directions...)
+			},
+		},
+		{
+			name: "DefaultHandler.GetAverageDistancesFromAllDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return h.GetAverageDistancesFromAllDirections(5)
+			},
+		},
+		{
+			name: "MockHandler.GetAverageDistancesFromDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return mock.GetAverageDistancesFromDirections(5, directions...)
+			},
+		},
+		{
+			name: "MockHandler.GetAverageDistancesWithWidths",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return mock.GetAverageDistancesWithWidths(widths)
+			},
+		},
+		{
+			name: "Scan.AverageDistancesFromPrimaryDirections",
+			avgDistances: func() (map[CardinalDirection]float64, error) {
+				return scan.AverageDistancesFromPrimaryDirections(5)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			avgDistances, err := test.avgDistances()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if avgDistance, ok := avgDistances[CardinalDirectionNorth]; !ok || avgDistance != 1000 {
+				t.Errorf("expected the north average distance 1000, got %f (present: %t)", avgDistance, ok)
+			}
+			if avgDistance, ok := avgDistances[CardinalDirectionEast]; ok {
+				t.Errorf("expected the east direction to be omitted, got %f", avgDistance)
+			}
+		})
+	}
+}
+
+// TestDetectClustersSeamMerge checks that the clusters at both sides of the 0/360 seam are merged into the last cluster
+// only if they meet the gap and distance jump conditions, keeping the clusters sorted by their start angle.
+func TestDetectClustersSeamMerge(t *testing.T) {
+	newMeasures := func(seamStartDistance float64) *[360]*Measure {
+		var measures [360]*Measure
+		setRange := func(startAngle, endAngle int, distance float64) {
+			for angle := startAngle; angle <= endAngle; angle++ {
+				measures[angle] = &Measure{angle: float64(angle), distance: distance, quality: 47}
+			}
+		}
+		setRange(0, 10, 1000)
+		setRange(100, 110, 2000)
+		setRange(200, 205, 3000)
+		setRange(350, 359, seamStartDistance)
+		return &measures
+	}
+
+	tests := []struct {
+		name             string
+		measures         *[360]*Measure
+		expectedClusters []Cluster
+	}{
+		{
+			name:     "merged across the seam",
+			measures: newMeasures(1050),
+			expectedClusters: []Cluster{
+				{StartAngle: 100, EndAngle: 110, MeanDistance: 2000, MinDistance: 2000, count: 11},
+				{StartAngle: 200, EndAngle: 205, MeanDistance: 3000, MinDistance: 3000, count: 6},
+				{StartAngle: 350, EndAngle: 10, MeanDistance: (10*1050 + 11*1000) / 21.0, MinDistance: 1000, count: 21},
+			},
+		},
+		{
+			name:     "distance jump at the seam",
+			measures: newMeasures(4000),
+			expectedClusters: []Cluster{
+				{StartAngle: 0, EndAngle: 10, MeanDistance: 1000, MinDistance: 1000, count: 11},
+				{StartAngle: 100, EndAngle: 110, MeanDistance: 2000, MinDistance: 2000, count: 11},
+				{StartAngle: 200, EndAngle: 205, MeanDistance: 3000, MinDistance: 3000, count: 6},
+				{StartAngle: 350, EndAngle: 359, MeanDistance: 4000, MinDistance: 4000, count: 10},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clusters := DetectClusters(test.measures, 5000, 2, 100)
+			if len(clusters) != len(test.expectedClusters) {
+				t.Fatalf("expected %d clusters, got %d: %+v", len(test.expectedClusters), len(clusters), clusters)
+			}
+			for i, expected := range test.expectedClusters {
+				cluster := clusters[i]
+				if cluster.StartAngle != expected.StartAngle ||
+					cluster.EndAngle != expected.EndAngle ||
+					cluster.count != expected.count ||
+					cluster.MinDistance != expected.MinDistance ||
+					math.Abs(cluster.MeanDistance-expected.MeanDistance) > 1e-9 {
+					t.Errorf("expected the cluster %d to be %+v, got %+v", i, expected, cluster)
+				}
+			}
+		})
+	}
+}